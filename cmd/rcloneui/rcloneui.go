@@ -5,33 +5,78 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/ricoberger/rcloneui/pkg/config"
 	"github.com/ricoberger/rcloneui/pkg/version"
 	"github.com/ricoberger/rcloneui/pkg/view"
 
 	_ "github.com/rclone/rclone/backend/all"
-	"github.com/rclone/rclone/fs/config"
+	"github.com/rclone/rclone/fs"
+	rcloneconfig "github.com/rclone/rclone/fs/config"
 	"github.com/rclone/rclone/fs/config/configfile"
 	"github.com/rivo/tview"
 	flag "github.com/spf13/pflag"
 )
 
 var (
-	maxAge      string
-	maxSize     string
-	minAge      string
-	minSize     string
-	showVersion bool
+	auditLog           string
+	dateFormat         string
+	dateRelative       bool
+	dateUTC            bool
+	deleteConfirmFiles int64
+	deleteConfirmSize  fs.SizeSuffix
+	dryRun             bool
+	listingCacheTTL    time.Duration
+	logFile            string
+	maxAge             string
+	maxSize            string
+	minAge             string
+	minSize            string
+	mouse              bool
+	openCommand        string
+	openInTerminal     bool
+	parentRow          bool
+	previewSize        string
+	readOnly           bool
+	rcloneuiConfig     string
+	refreshInterval    time.Duration
+	refreshPoll        bool
+	trash              bool
+	trashPath          string
+	undoLimit          int
+	showVersion        bool
 )
 
 // init is used to define all flags for rcloneui. For example we define the --version flag here, which can be used to
 // print the version information of rcloneui.
 func init() {
+	flag.StringVar(&auditLog, "audit-log", "", "Path of the audit log file, which records all actions which modify a remote.")
+	flag.StringVar(&dateFormat, "date-format", view.DefaultTimeLayout, "Go time layout which is used to format dates.")
+	flag.BoolVar(&dateRelative, "date-relative", false, "Show dates relative to the current time, e.g. \"3h ago\".")
+	flag.BoolVar(&dateUTC, "date-utc", false, "Show dates in UTC instead of the local time zone.")
+	flag.Int64Var(&deleteConfirmFiles, "delete-confirm-files", 0, "Confirm deletions with more files than this. Use -1 to disable the confirmation.")
+	flag.Var(&deleteConfirmSize, "delete-confirm-size", "Confirm deletions larger than this in k or suffix b|k|M|G. Use off to disable the confirmation.")
+	flag.BoolVar(&dryRun, "dry-run", false, "Only simulate actions which modify a remote, like paste and delete.")
+	flag.DurationVar(&listingCacheTTL, "listing-cache-ttl", time.Minute, "Duration for which the listings of folders are cached. Use 0 to disable the cache.")
+	flag.StringVar(&logFile, "log-file", "", "Path of the file to which the log messages of rclone are written. By default the log messages are discarded, because they would break the terminal ui.")
 	flag.StringVar(&maxAge, "max-age", "off", "Only transfer files younger than this in s or suffix ms|s|m|h|d|w|M|y.")
 	flag.StringVar(&maxSize, "max-size", "off", "Only transfer files smaller than this in k or suffix b|k|M|G.")
 	flag.StringVar(&minAge, "min-age", "off", "Only transfer files older than this in s or suffix ms|s|m|h|d|w|M|y.")
 	flag.StringVar(&minSize, "min-size", "off", "Only transfer files bigger than this in k or suffix b|k|M|G.")
+	flag.BoolVar(&mouse, "mouse", true, "Enable mouse support. Use --mouse=false to select text in the terminal.")
+	flag.StringVar(&openCommand, "open-command", view.DefaultOpenCommand(), "Command which is used to open files.")
+	flag.BoolVar(&openInTerminal, "open-in-terminal", false, "The open command is a terminal program, so that rcloneui is suspended while it runs.")
+	flag.BoolVar(&parentRow, "parent-row", false, "Show a \"..\" row above the files and folders, which can be selected to go up a folder.")
+	flag.StringVar(&previewSize, "preview-size", "16k", "Number of bytes which are shown in the preview of a file in k or suffix b|k|M|G.")
+	flag.BoolVar(&readOnly, "read-only", false, "Disable all actions which modify a remote, like paste and delete.")
+	flag.StringVar(&rcloneuiConfig, "rcloneui-config", config.DefaultPath(), "Path to the rcloneui config file.")
+	flag.DurationVar(&refreshInterval, "refresh-interval", time.Minute, "Interval in which remotes are checked for changes. Use 0 to disable the automatic refresh for remotes.")
+	flag.BoolVar(&refreshPoll, "refresh-poll", false, "List the current folder again in the refresh interval for remotes, which do not support change notifications.")
+	flag.BoolVar(&trash, "trash", false, "Move deleted files and folders into the trash instead of deleting them.")
+	flag.StringVar(&trashPath, "trash-path", ".rcloneui-trash", "Folder which is used for the trash. The folder is relative to the root of a remote and to the home directory for local files. It can be set per remote via trashPaths in the rcloneui config.")
+	flag.IntVar(&undoLimit, "undo-limit", 10, "Number of deletions which can be restored via the undo action, when the trash is enabled.")
 	flag.BoolVar(&showVersion, "version", false, "Print version information.")
 }
 
@@ -50,10 +95,18 @@ func main() {
 		return
 	}
 
+	// rclone writes its log messages (e.g. for skipped symlinks) to stderr, which would break the rendering of the
+	// terminal ui, so that we write them to the log file instead.
+	logger, err := view.NewLogger(logFile)
+	if err != nil {
+		log.Fatalf("Could not create log file: %#v", err)
+	}
+	fs.LogOutput = logger.Output
+
 	// Load the rclone configuration file and get a list of all sections. The sections are always used as entrypoint for
 	// the rcloneui.
 	configfile.Install()
-	remotes := config.LoadedData().GetSectionList()
+	remotes := rcloneconfig.LoadedData().GetSectionList()
 	remotes = append([]string{view.Local}, remotes...)
 
 	// Get the users current directory, which is used as destination for downloading files.
@@ -62,28 +115,117 @@ func main() {
 		log.Fatalf("Could not get current directory: %#v", err)
 	}
 
-	// Initialize the status bar, the two views and the grid, which then are rendered via tview. After the views are
-	// initialized we have to pass the other view to a view, so that we can switch the focus via the tab key.
+	// Initialize the pages, the status bar, the two views and the grid, which then are rendered via tview. After the
+	// views are initialized we have to pass the other view to a view, so that we can switch the focus via the tab key.
 	app := tview.NewApplication()
+	pages := tview.NewPages()
 
 	filter, err := view.CreateFilter(minAge, maxAge, minSize, maxSize)
 	if err != nil {
 		log.Fatalf("Could not create filter: %#v", err)
 	}
 
-	status := view.NewStatus(app)
-	view1 := view.NewView(app, status, remotes, strings.Split(userDir, "/"), filter)
-	view2 := view.NewView(app, status, remotes, strings.Split(userDir, "/"), filter)
+	preview, err := view.NewPreview(app, pages, previewSize)
+	if err != nil {
+		log.Fatalf("Could not create preview: %#v", err)
+	}
+
+	// Load the rcloneui configuration file. The configuration file is optional, so that we only fail when the file
+	// exists, but can not be parsed. The --read-only, --dry-run and --audit-log flags always overwrite the settings from
+	// the configuration file.
+	cfg, err := config.Load(rcloneuiConfig)
+	if err != nil {
+		log.Fatalf("Could not load rcloneui config: %#v", err)
+	}
+
+	if readOnly {
+		cfg.ReadOnly = true
+	}
+
+	if dryRun {
+		cfg.DryRun = true
+	}
+
+	if auditLog != "" {
+		cfg.AuditLog = auditLog
+	}
+
+	options := view.Options{
+		OpenCommand:        strings.Fields(openCommand),
+		OpenInTerminal:     openInTerminal,
+		DeleteConfirmFiles: deleteConfirmFiles,
+		DeleteConfirmSize:  deleteConfirmSize,
+		Config:             cfg,
+		Logger:             logger,
+		Cache:              view.NewListingCache(listingCacheTTL),
+		RefreshInterval:    refreshInterval,
+		RefreshPoll:        refreshPoll,
+		ParentRow:          parentRow,
+		TimeFormat:         view.NewTimeFormat(dateFormat, dateUTC, dateRelative),
+	}
+
+	if len(options.OpenCommand) == 0 {
+		log.Fatalf("Open command can not be empty")
+	}
+
+	if cfg.AuditLog != "" {
+		options.AuditLog, err = view.NewAuditLog(cfg.AuditLog)
+		if err != nil {
+			log.Fatalf("Could not create audit log: %#v", err)
+		}
+	}
+
+	if trash {
+		options.Trash, err = view.NewTrash(trashPath, cfg.TrashPaths, undoLimit)
+		if err != nil {
+			log.Fatalf("Could not create trash: %#v", err)
+		}
+	}
+
+	status := view.NewStatus(app, pages)
+	status.SetDryRun(cfg.DryRun)
+	// Both views are using the same options, except the index of the view, which is used for the view specific settings.
+	options2 := options
+	options2.Pane = 1
+
+	// Each view has its own breadcrumb, which is rendered above the view.
+	breadcrumb1 := view.NewBreadcrumb(pages)
+	breadcrumb2 := view.NewBreadcrumb(pages)
+
+	view1 := view.NewView(app, pages, status, preview, breadcrumb1, remotes, strings.Split(userDir, "/"), filter, options)
+	view2 := view.NewView(app, pages, status, preview, breadcrumb2, remotes, strings.Split(userDir, "/"), filter, options2)
 
 	view1.SetView(view2)
 	view2.SetView(view1)
 
-	grid := tview.NewGrid().SetRows(0, 1).SetColumns(0, 0).SetBorders(true)
+	grid := tview.NewGrid().SetRows(1, 0, 1).SetColumns(0, 0).SetBorders(true)
 	grid.SetBordersColor(tcell.ColorBlack)
-	grid.AddItem(view1, 0, 0, 1, 1, 0, 0, true).AddItem(view2, 0, 1, 1, 1, 0, 0, false)
-	grid.AddItem(status, 1, 0, 1, 2, 0, 0, false)
+	grid.AddItem(breadcrumb1, 0, 0, 1, 1, 0, 0, false).AddItem(breadcrumb2, 0, 1, 1, 1, 0, 0, false)
+	grid.AddItem(view1, 1, 0, 1, 1, 0, 0, true).AddItem(view2, 1, 1, 1, 1, 0, 0, false)
+	grid.AddItem(status, 2, 0, 1, 2, 0, 0, false)
+
+	// The preview is rendered as third column of the grid. Because it is hidden by default, we have to add or remove it
+	// from the grid when the user toggles the preview. The preview uses the rows of the breadcrumbs and views, while the
+	// status bar must always use the full width of the grid.
+	preview.SetToggleFunc(func(visible bool) {
+		grid.RemoveItem(status)
+
+		if visible {
+			grid.SetColumns(0, 0, 0)
+			grid.AddItem(preview, 0, 2, 2, 1, 0, 0, false)
+			grid.AddItem(status, 2, 0, 1, 3, 0, 0, false)
+		} else {
+			grid.RemoveItem(preview)
+			grid.SetColumns(0, 0)
+			grid.AddItem(status, 2, 0, 1, 2, 0, 0, false)
+		}
+	})
+
+	// The grid is added as the main page. Additional pages are used by the views to render overlays, like the hashes of
+	// a file, on top of the grid.
+	pages.AddPage("main", grid, true, true)
 
-	if err := app.SetRoot(grid, true).SetFocus(grid).Run(); err != nil {
+	if err := app.SetRoot(pages, true).SetFocus(grid).EnableMouse(mouse).Run(); err != nil {
 		log.Fatalf("Could not render view: %#v", err)
 	}
 }
@@ -3,10 +3,12 @@ module github.com/ricoberger/rcloneui
 go 1.23
 
 require (
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/gdamore/tcell/v2 v2.8.1
 	github.com/rclone/rclone v1.69.0
 	github.com/rivo/tview v0.0.0-20240116070845-bf8f1c43e46c
 	github.com/spf13/pflag v1.0.6
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -187,7 +189,6 @@ require (
 	google.golang.org/protobuf v1.35.2 // indirect
 	gopkg.in/validator.v2 v2.0.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	moul.io/http2curl/v2 v2.3.0 // indirect
 	storj.io/common v0.0.0-20240812101423-26b53789c348 // indirect
 	storj.io/drpc v0.0.35-0.20240709171858-0075ac871661 // indirect
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Column is a column, which is shown in a view. The expansion is the relative width of the column compared to the other
+// columns and the width is the maximum width of the column in characters, where 0 means no limit.
+type Column struct {
+	Name      string `yaml:"name"`
+	Expansion int    `yaml:"expansion"`
+	Width     int    `yaml:"width"`
+}
+
+// Pane contains the settings for one of the two views.
+type Pane struct {
+	// Columns is the list of columns, which are shown in the view. When the list is empty the default columns are
+	// shown.
+	Columns []Column `yaml:"columns"`
+	// HideHidden hides all files and folders, which names are starting with a ".".
+	HideHidden bool `yaml:"hideHidden"`
+}
+
+// Config is the rcloneui configuration, which is loaded from the rcloneui config file. The configuration file is
+// optional, so that all fields must have a useful zero value.
+type Config struct {
+	// ReadOnly disables all actions which modify a remote (e.g. paste and delete) for all remotes.
+	ReadOnly bool `yaml:"readOnly"`
+	// ProtectedRemotes is a list of remotes for which all actions which modify a remote are disabled.
+	ProtectedRemotes []string `yaml:"protectedRemotes"`
+	// DryRun enables the dry-run mode, where all actions which modify a remote are only simulated.
+	DryRun bool `yaml:"dryRun"`
+	// AuditLog is the path of the audit log file. When the path is set, all actions which modify a remote are
+	// recorded in the audit log.
+	AuditLog string `yaml:"auditLog"`
+	// Bookmarks is a list of locations (e.g. "remote:path" or an absolute local path), which are shown together with
+	// the remotes and can be used to jump directly to the location.
+	Bookmarks []string `yaml:"bookmarks"`
+	// TrashPaths contains the folder of the trash for single remotes, which is used instead of the global trash path.
+	// This is required for bucket-based remotes (e.g. S3), where the trash must be a folder within a bucket. For local
+	// files the folder can be an absolute path or a path relative to the users home directory.
+	TrashPaths map[string]string `yaml:"trashPaths"`
+	// Panes contains the settings for the two views, where the first item is used for the left view and the second
+	// item for the right view.
+	Panes []Pane `yaml:"panes"`
+
+	path string
+	file fileSettings
+}
+
+// fileSettings contains the settings from the configuration file, which can be overwritten via flags or changed at
+// runtime. These settings must not be saved, so that we always save the values from the file instead.
+type fileSettings struct {
+	readOnly bool
+	dryRun   bool
+	auditLog string
+}
+
+// IsProtected returns true when the given remote can not be modified. This is the case when the read-only mode is
+// enabled or when the remote is in the list of protected remotes.
+func (c *Config) IsProtected(remote string) bool {
+	if c.ReadOnly {
+		return true
+	}
+
+	for _, protectedRemote := range c.ProtectedRemotes {
+		if protectedRemote == remote {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddBookmark adds the given location to the list of bookmarks. It returns false when the location was already
+// bookmarked.
+func (c *Config) AddBookmark(location string) bool {
+	for _, bookmark := range c.Bookmarks {
+		if bookmark == location {
+			return false
+		}
+	}
+
+	c.Bookmarks = append(c.Bookmarks, location)
+	return true
+}
+
+// RemoveBookmark removes the given location from the list of bookmarks.
+func (c *Config) RemoveBookmark(location string) {
+	for i, bookmark := range c.Bookmarks {
+		if bookmark == location {
+			c.Bookmarks = append(c.Bookmarks[:i], c.Bookmarks[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetColumns sets the columns for the view with the given index.
+func (c *Config) SetColumns(pane int, columns []Column) {
+	c.pane(pane).Columns = columns
+}
+
+// SetHideHidden sets if hidden files and folders are hidden in the view with the given index.
+func (c *Config) SetHideHidden(pane int, hideHidden bool) {
+	c.pane(pane).HideHidden = hideHidden
+}
+
+// pane returns the settings for the view with the given index. When the settings do not exist yet, they are added.
+func (c *Config) pane(pane int) *Pane {
+	for len(c.Panes) <= pane {
+		c.Panes = append(c.Panes, Pane{})
+	}
+
+	return &c.Panes[pane]
+}
+
+// Save writes the configuration back to the file it was loaded from. The folder of the file is created if it does not
+// exist. The read-only mode, the dry-run mode and the audit log are always saved with the values from the file, because
+// they can be overwritten via flags or changed at runtime.
+func (c *Config) Save() error {
+	file := *c
+	file.ReadOnly = c.file.readOnly
+	file.DryRun = c.file.dryRun
+	file.AuditLog = c.file.auditLog
+
+	data, err := yaml.Marshal(&file)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(c.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, data, 0644)
+}
+
+// DefaultPath returns the default path of the rcloneui config file, which is "rcloneui/config.yaml" in the users
+// config directory.
+func DefaultPath() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "rcloneui.yaml"
+	}
+
+	return filepath.Join(configDir, "rcloneui", "config.yaml")
+}
+
+// Load loads the configuration from the given path. When the file does not exist an empty configuration is returned.
+func Load(path string) (*Config, error) {
+	config := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, err
+	}
+
+	config.file = fileSettings{config.ReadOnly, config.DryRun, config.AuditLog}
+
+	return config, nil
+}
@@ -0,0 +1,183 @@
+package view
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rclone/rclone/fs"
+	"github.com/rivo/tview"
+)
+
+// auditEntry is a single entry in the audit log. An entry is written for each action which modifies a remote.
+type auditEntry struct {
+	Time        time.Time `json:"time"`
+	User        string    `json:"user"`
+	Host        string    `json:"host"`
+	Action      string    `json:"action"`
+	Source      string    `json:"source,omitempty"`
+	Destination string    `json:"destination,omitempty"`
+	Bytes       int64     `json:"bytes"`
+	Duration    string    `json:"duration"`
+	DryRun      bool      `json:"dryRun,omitempty"`
+	Outcome     string    `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// description returns a human readable description of the action of the entry, e.g. "paste local:/a to remote:b".
+func (e auditEntry) description() string {
+	if e.Destination == "" {
+		return fmt.Sprintf("%s %s", e.Action, e.Source)
+	}
+
+	return fmt.Sprintf("%s %s to %s", e.Action, e.Source, e.Destination)
+}
+
+type AuditLog struct {
+	path string
+	user string
+	host string
+	mu   sync.Mutex
+}
+
+// write appends the given entry to the audit log. The user and host of the entry are set by the audit log.
+func (a *AuditLog) write(entry auditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry.User = a.user
+	entry.Host = a.host
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// read returns all entries from the audit log, starting with the last entry. Lines which can not be parsed are
+// ignored.
+func (a *AuditLog) read() ([]auditEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	file, err := os.Open(a.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []auditEntry
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append([]auditEntry{entry}, entries...)
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// NewAuditLog returns a new audit log, which writes all entries to the file at the given path. The user and host
+// which are written in each entry are determined once when the audit log is created.
+func NewAuditLog(path string) (*AuditLog, error) {
+	username := os.Getenv("USER")
+	if u, err := user.Current(); err == nil {
+		username = u.Username
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLog{
+		path: path,
+		user: username,
+		host: host,
+	}, nil
+}
+
+// showAuditLog renders all entries of the audit log in an overlay.
+func (v *View) showAuditLog() {
+	entries, err := v.options.AuditLog.read()
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not read audit log: %#v", err)
+	}
+
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false).SetBorders(false)
+	table.SetBorder(true).SetTitle(" Audit Log ").SetBorderColor(tcell.ColorBlue)
+
+	for i, header := range []string{"TIME", "USER", "ACTION", "SOURCE", "DESTINATION", "BYTES", "DURATION", "OUTCOME"} {
+		table.SetCell(0, i, tview.NewTableCell(header).SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetSelectable(false))
+	}
+
+	// Actions in the dry-run mode are only simulated, so that they are marked in the action column (the outcome column
+	// is often cut off) and rendered in yellow like the dry-run mode in the status bar. Failed actions are always
+	// rendered in red.
+	for i, entry := range entries {
+		color := tcell.ColorBlue
+		if entry.DryRun {
+			color = tcell.ColorYellow
+		}
+		if entry.Error != "" {
+			color = tcell.ColorRed
+		}
+
+		action := entry.Action
+		if entry.DryRun {
+			action = fmt.Sprintf("%s (dry-run)", entry.Action)
+		}
+
+		outcome := entry.Outcome
+		if entry.Error != "" {
+			outcome = fmt.Sprintf("%s: %s", entry.Outcome, entry.Error)
+		}
+
+		for j, value := range []string{
+			v.options.TimeFormat.format(entry.Time),
+			fmt.Sprintf("%s@%s", entry.User, entry.Host),
+			action,
+			entry.Source,
+			entry.Destination,
+			fs.SizeSuffix(entry.Bytes).ByteUnit(),
+			entry.Duration,
+			outcome,
+		} {
+			table.SetCell(i+1, j, tview.NewTableCell(value).SetTextColor(color).SetAlign(tview.AlignLeft))
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			v.hideOverlay()
+			return nil
+		}
+
+		return event
+	})
+
+	v.showOverlay(table)
+}
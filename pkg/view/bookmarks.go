@@ -0,0 +1,95 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// location returns the current location of the view in the format which is used for the location bar and bookmarks.
+// For the root of a remote fsPath would return "remote:", which is also what we want.
+func (v *View) location() string {
+	return fsPath(v.remote, v.remotePath)
+}
+
+// saveBookmarks writes the bookmarks to the rcloneui config file and renders the rows of the remotes table of both
+// views again, when they are showing it, so that the changed bookmarks are visible. Only the rows are rendered, so that
+// the history, the status bar and the preview are not changed.
+func (v *View) saveBookmarks() {
+	if err := v.options.Config.Save(); err != nil {
+		v.showText("Error", fmt.Sprintf("Could not save bookmarks: %s", tview.Escape(err.Error())))
+		return
+	}
+
+	for _, view := range []*View{v, v.otherView} {
+		if view != nil && view.remote == "" {
+			view.renderRemoteRows()
+		}
+	}
+}
+
+// addBookmark adds the current location of the view to the bookmarks.
+func (v *View) addBookmark() {
+	if v.options.Config.AddBookmark(v.location()) {
+		v.saveBookmarks()
+		v.showText("Bookmarks", fmt.Sprintf("Added bookmark for [blue]%s[white].", tview.Escape(v.location())))
+	} else {
+		v.showText("Bookmarks", fmt.Sprintf("The location [blue]%s[white] is already bookmarked.", tview.Escape(v.location())))
+	}
+}
+
+// showBookmarks renders all bookmarks in an overlay. The "enter" key jumps to the selected bookmark in the current
+// view and the "o" key jumps to the selected bookmark in the other view. The "d" key removes the selected bookmark.
+func (v *View) showBookmarks() {
+	table := tview.NewTable().SetSelectable(true, false).SetBorders(false)
+	table.SetBorder(true).SetTitle(" Bookmarks ").SetBorderColor(tcell.ColorBlue)
+
+	render := func() {
+		table.Clear()
+
+		for i, bookmark := range v.options.Config.Bookmarks {
+			table.SetCell(i, 0, tview.NewTableCell(bookmark).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft).SetExpansion(1))
+		}
+	}
+
+	render()
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			v.hideOverlay()
+			return nil
+		}
+
+		row, _ := table.GetSelection()
+		if row < 0 || row >= len(v.options.Config.Bookmarks) {
+			return event
+		}
+
+		bookmark := v.options.Config.Bookmarks[row]
+
+		if event.Key() == tcell.KeyEnter {
+			v.hideOverlay()
+			v.jump(bookmark)
+			return nil
+		}
+
+		if event.Rune() == 'o' && v.otherView != nil {
+			v.hideOverlay()
+			v.otherView.jump(bookmark)
+			v.app.SetFocus(v.otherView)
+			return nil
+		}
+
+		if event.Rune() == 'd' {
+			v.options.Config.RemoveBookmark(bookmark)
+			v.saveBookmarks()
+			render()
+			return nil
+		}
+
+		return event
+	})
+
+	v.showOverlay(table)
+}
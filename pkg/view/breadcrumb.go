@@ -0,0 +1,204 @@
+package view
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	// breadcrumbRemotes is the id of the region, which is used to jump back to the list of remotes.
+	breadcrumbRemotes = "remotes"
+)
+
+// Breadcrumb shows the location of a view above the table. Each segment of the location is rendered as region, where
+// the id of the region is the number of path elements of the segment. A segment can be selected via a click or via the
+// keyboard to jump directly to the folder of the segment.
+type Breadcrumb struct {
+	*tview.TextView
+
+	pages *tview.Pages
+
+	remote string
+	path   []string
+	active bool
+
+	selectedFunc func(segment string)
+	doneFunc     func()
+}
+
+// render renders the breadcrumb. The first segment is always the list of remotes, followed by the remote and the
+// folders of the current path. The breadcrumb of the focused view is rendered in white, the other one in gray.
+func (b *Breadcrumb) render() {
+	color := "gray"
+	if b.active {
+		color = "white"
+	}
+
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "[%s][\"%s\"]remotes[\"\"]", color, breadcrumbRemotes)
+
+	if b.remote != "" {
+		text.WriteString(" › ")
+
+		// For local paths the first path element is always empty, because the path is absolute. This element is rendered
+		// together with the remote, but it can not be selected, because the empty path can not be listed.
+		start := 0
+		if b.firstSegment() == 0 {
+			fmt.Fprintf(&text, "[\"0\"]%s:[\"\"]", tview.Escape(b.remote))
+		} else {
+			fmt.Fprintf(&text, "%s:/", tview.Escape(b.remote))
+			start = 1
+		}
+
+		// The segment of a path element contains all path elements up to and including the element.
+		for i := start; i < len(b.path); i++ {
+			if i > start {
+				text.WriteString("/")
+			}
+
+			fmt.Fprintf(&text, "[\"%d\"]%s[\"\"]", i+1, tview.Escape(b.path[i]))
+		}
+	}
+
+	b.SetText(text.String())
+}
+
+// firstSegment returns the number of path elements of the first segment, which can be selected. For local paths this
+// is the first folder after the root of the filesystem.
+func (b *Breadcrumb) firstSegment() int {
+	if b.remote == Local && len(b.path) > 0 && b.path[0] == "" {
+		return 2
+	}
+
+	return 0
+}
+
+// selectSegment highlights the segment before (-1) or after (1) the currently highlighted segment.
+func (b *Breadcrumb) selectSegment(step int) {
+	segments := []string{breadcrumbRemotes}
+	if b.remote != "" {
+		for i := b.firstSegment(); i <= len(b.path); i++ {
+			segments = append(segments, strconv.Itoa(i))
+		}
+	}
+
+	current := len(segments) - 1
+	if highlights := b.GetHighlights(); len(highlights) > 0 {
+		for i, segment := range segments {
+			if segment == highlights[0] {
+				current = i
+			}
+		}
+	}
+
+	if current+step >= 0 && current+step < len(segments) {
+		current = current + step
+	}
+
+	b.Highlight(segments[current])
+}
+
+// MouseHandler returns the mouse handler of the breadcrumb. Clicking a segment jumps to the folder of the segment. The
+// breadcrumb itself never gets the focus via the mouse. While an overlay is shown, all mouse events are ignored,
+// because the overlay does not cover the breadcrumb and a click would change the view below the overlay.
+func (b *Breadcrumb) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	handler := b.TextView.MouseHandler()
+
+	return func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if b.pages.HasPage(overlayPage) {
+			return false, nil
+		}
+
+		if action == tview.MouseLeftDown {
+			return b.InRect(event.Position()), nil
+		}
+
+		consumed, capture = handler(action, event, setFocus)
+
+		if action == tview.MouseLeftClick && consumed {
+			if highlights := b.GetHighlights(); len(highlights) > 0 {
+				b.Highlight()
+				b.selectedFunc(highlights[0])
+				b.doneFunc()
+			}
+		}
+
+		return consumed, capture
+	}
+}
+
+// Select is used to select a segment via the keyboard. The last segment is highlighted first. The "left" and "right"
+// keys (or "h" and "l") highlight the previous and next segment, the "enter" key jumps to the highlighted segment and
+// the "escape" key cancels the selection.
+func (b *Breadcrumb) Select() {
+	b.Highlight()
+	b.selectSegment(0)
+}
+
+// SetLocation sets the remote and path, which are shown in the breadcrumb. For the list of remotes the remote is empty.
+func (b *Breadcrumb) SetLocation(remote string, path []string) {
+	b.remote = remote
+	b.path = path
+
+	b.render()
+}
+
+// SetActive is used to show if the view of the breadcrumb is focused.
+func (b *Breadcrumb) SetActive(active bool) {
+	b.active = active
+
+	b.render()
+}
+
+// SetSelectedFunc sets the function, which is called when the user selects a segment. The function is called with the
+// id of the region of the segment, which is "remotes" for the list of remotes or the number of path elements. The done
+// function is called afterwards and when the user cancels the selection, so that the focus can be returned to the view.
+func (b *Breadcrumb) SetSelectedFunc(selectedFunc func(segment string), doneFunc func()) {
+	b.selectedFunc = selectedFunc
+	b.doneFunc = doneFunc
+}
+
+// NewBreadcrumb returns the breadcrumb component, which shows the location of a view. The pages are used to check if
+// an overlay is shown.
+func NewBreadcrumb(pages *tview.Pages) *Breadcrumb {
+	text := tview.NewTextView().SetDynamicColors(true).SetRegions(true).SetWrap(false).SetTextAlign(tview.AlignLeft)
+
+	b := &Breadcrumb{
+		text,
+		pages,
+		"",
+		nil,
+		false,
+		func(segment string) {},
+		func() {},
+	}
+
+	b.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyLeft || event.Rune() == 'h':
+			b.selectSegment(-1)
+		case event.Key() == tcell.KeyRight || event.Rune() == 'l':
+			b.selectSegment(1)
+		case event.Key() == tcell.KeyEnter:
+			if highlights := b.GetHighlights(); len(highlights) > 0 {
+				b.Highlight()
+				b.selectedFunc(highlights[0])
+			}
+			b.doneFunc()
+		case event.Key() == tcell.KeyEscape || event.Rune() == 'q':
+			b.Highlight()
+			b.doneFunc()
+		}
+
+		return nil
+	})
+
+	b.render()
+
+	return b
+}
@@ -0,0 +1,88 @@
+package view
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+)
+
+// listing is a cached listing of a folder.
+type listing struct {
+	entries fs.DirEntries
+	time    time.Time
+}
+
+// ListingCache caches the listings of folders, so that a folder must not be listed again each time the user navigates
+// to it. The listings are cached by the fsPath of the folder for the configured ttl. A ttl of 0 disables the cache.
+// The cache is shared between both views, so that changes made in one view are also visible in the other view.
+type ListingCache struct {
+	ttl      time.Duration
+	listings map[string]listing
+	mu       sync.Mutex
+}
+
+// get returns the cached listing for the given fsPath. The second return value is false when the listing is not cached
+// or when the listing is older than the ttl.
+func (c *ListingCache) get(path string) (fs.DirEntries, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.listings[path]
+	if !ok || time.Since(l.time) > c.ttl {
+		delete(c.listings, path)
+		return nil, false
+	}
+
+	return l.entries, true
+}
+
+// set adds the listing for the given fsPath to the cache.
+func (c *ListingCache) set(path string, entries fs.DirEntries) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listings[path] = listing{entries, time.Now()}
+}
+
+// invalidate removes the listing for the given fsPath from the cache.
+func (c *ListingCache) invalidate(path string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.listings, path)
+}
+
+// clear removes all listings from the cache. This is used after an action which modifies a remote, because an action
+// can change multiple folders (e.g. a deletion with the trash enabled).
+func (c *ListingCache) clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listings = make(map[string]listing)
+}
+
+// NewListingCache returns a new listing cache with the given ttl.
+func NewListingCache(ttl time.Duration) *ListingCache {
+	return &ListingCache{
+		ttl,
+		make(map[string]listing),
+		sync.Mutex{},
+	}
+}
@@ -0,0 +1,337 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/hash"
+	"github.com/ricoberger/rcloneui/pkg/config"
+	"github.com/rivo/tview"
+)
+
+var (
+	// defaultColumns are the columns, which are shown when no columns are configured for a view.
+	defaultColumns = []config.Column{{Name: "name", Expansion: 5}, {Name: "size", Expansion: 2}, {Name: "date", Expansion: 2}}
+)
+
+// availableColumns returns the names of all columns, which can be shown in a view. Besides the fixed columns, there is
+// a column for each supported hash type.
+func availableColumns() []string {
+	columns := []string{"name", "size", "date", "mime", "tier", "id", "permissions", "children"}
+	for _, ht := range hash.Supported().Array() {
+		columns = append(columns, ht.String())
+	}
+
+	return columns
+}
+
+// isSlowColumn returns true for columns, which can not be rendered directly, because the value requires reading the
+// whole file (hashes) or listing a folder (children).
+func isSlowColumn(name string) bool {
+	if name == "children" {
+		return true
+	}
+
+	var ht hash.Type
+	return ht.Set(name) == nil
+}
+
+// columns returns the configured columns of the view or the default columns, when no columns are configured.
+// The name column is always returned as first column, because the tree prefixes, the search highlighting and the
+// columns overlay depend on it. When the name column is missing or moved in the rcloneui config, it is added or moved
+// to the front.
+func (v *View) columns() []config.Column {
+	if v.options.Pane >= len(v.options.Config.Panes) || len(v.options.Config.Panes[v.options.Pane].Columns) == 0 {
+		return defaultColumns
+	}
+
+	configured := v.options.Config.Panes[v.options.Pane].Columns
+	if configured[0].Name == "name" {
+		return configured
+	}
+
+	columns := []config.Column{defaultColumns[0]}
+	for _, column := range configured {
+		if column.Name == "name" {
+			columns[0] = column
+		} else {
+			columns = append(columns, column)
+		}
+	}
+
+	return columns
+}
+
+// columnValue returns the value of the column with the given name for the file/folder in the given row. The values of
+// slow columns are returned by slowColumnValue.
+func (v *View) columnValue(ctx context.Context, name string, row int) string {
+	entry := v.remoteEntries[v.entryIndex(row)]
+
+	switch name {
+	case "name":
+		return entryName(entry)
+	case "size":
+		return fmt.Sprintf("%d", entry.Size())
+	case "date":
+		return v.options.TimeFormat.format(entry.ModTime(ctx))
+	case "mime":
+		return fs.MimeTypeDirEntry(ctx, entry)
+	case "tier":
+		if do, ok := entry.(fs.GetTierer); ok {
+			return do.GetTier()
+		}
+	case "id":
+		if do, ok := entry.(fs.IDer); ok {
+			return do.ID()
+		}
+	case "permissions":
+		if v.remote == Local {
+			if fi, err := os.Lstat(fsPath(Local, v.entryPath(row))); err == nil {
+				return fi.Mode().String()
+			}
+		}
+	}
+
+	return ""
+}
+
+// slowColumnValue returns the value of a slow column for the given file/folder. For the children column the folder is
+// listed via the given Fs of the current folder, when the number of items is not known.
+func (v *View) slowColumnValue(ctx context.Context, f fs.Fs, name string, entry fs.DirEntry) string {
+	if name == "children" {
+		dir, ok := entry.(fs.Directory)
+		if !ok {
+			return ""
+		}
+
+		if dir.Items() >= 0 {
+			return fmt.Sprintf("%d", dir.Items())
+		}
+
+		entries, err := v.listEntries(ctx, f, dir.Remote())
+		if err != nil {
+			return ""
+		}
+
+		return fmt.Sprintf("%d", len(entries))
+	}
+
+	o, ok := entry.(fs.Object)
+	if !ok {
+		return ""
+	}
+
+	var ht hash.Type
+	if err := ht.Set(name); err != nil {
+		return ""
+	}
+
+	sum, err := o.Hash(ctx, ht)
+	if err != nil {
+		return ""
+	}
+
+	return sum
+}
+
+// loadSlowColumns renders the values of all slow columns in the background, so that the user does not have to wait
+// until all hashes are computed. Loading the values of the previous entries is always canceled.
+func (v *View) loadSlowColumns() {
+	if v.columnsCancel != nil {
+		v.columnsCancel()
+		v.columnsCancel = nil
+	}
+
+	var slow []int
+	for i, column := range v.columns() {
+		if isSlowColumn(column.Name) {
+			slow = append(slow, i)
+		}
+	}
+
+	if len(slow) == 0 || v.remote == "" {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.columnsCancel = cancel
+
+	columns := v.columns()
+	entries := v.remoteEntries
+	path := fsPath(v.remote, v.remotePath)
+
+	go func() {
+		f, err := newFs(ctx, path)
+		if err != nil {
+			return
+		}
+
+		for i, entry := range entries {
+			values := make([]string, len(slow))
+			for j, column := range slow {
+				values[j] = v.slowColumnValue(ctx, f, columns[column].Name, entry)
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			v.app.QueueUpdateDraw(func() {
+				// The entries could be rendered again in the meantime, so that we have to check if the loading was
+				// canceled, before we update the cells.
+				if ctx.Err() != nil {
+					return
+				}
+
+				for j, column := range slow {
+					v.GetCell(v.entryRow(i), column).SetText(values[j])
+				}
+			})
+		}
+	}()
+}
+
+// showColumns renders all available columns in an overlay, where the user can select the columns for the view. The
+// visible columns are shown first in the order they are rendered. The "enter" key shows or hides the selected column,
+// the "K" and "J" keys move the column up or down, the "+" and "-" keys change the expansion and the "w" key sets the
+// maximum width of the column. The name column is always shown as first column. All changes are saved in the rcloneui
+// config.
+func (v *View) showColumns(localPath []string) {
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false).SetBorders(false)
+	table.SetBorder(true).SetTitle(" Columns ").SetBorderColor(tcell.ColorBlue)
+
+	// rows returns the visible columns followed by all hidden columns, so that we can map a row of the table to a
+	// column. The number of visible columns is returned as second value.
+	rows := func() ([]config.Column, int) {
+		columns := append([]config.Column{}, v.columns()...)
+		visible := len(columns)
+
+		for _, name := range availableColumns() {
+			found := false
+			for _, column := range columns[:visible] {
+				if column.Name == name {
+					found = true
+				}
+			}
+
+			if !found {
+				columns = append(columns, config.Column{Name: name, Expansion: 1})
+			}
+		}
+
+		return columns, visible
+	}
+
+	render := func() {
+		table.Clear()
+		table.SetCell(0, 0, tview.NewTableCell("SHOW").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetSelectable(false))
+		table.SetCell(0, 1, tview.NewTableCell("COLUMN").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(1).SetSelectable(false))
+		table.SetCell(0, 2, tview.NewTableCell("EXPANSION").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetSelectable(false))
+		table.SetCell(0, 3, tview.NewTableCell("WIDTH").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetSelectable(false))
+
+		columns, visible := rows()
+
+		for i, column := range columns {
+			show, expansion, width := "[ ]", "", ""
+			if i < visible {
+				show, expansion, width = "[x[]", strconv.Itoa(column.Expansion), "-"
+				if column.Width > 0 {
+					width = strconv.Itoa(column.Width)
+				}
+			}
+
+			table.SetCell(i+1, 0, tview.NewTableCell(show).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			table.SetCell(i+1, 1, tview.NewTableCell(column.Name).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			table.SetCell(i+1, 2, tview.NewTableCell(expansion).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			table.SetCell(i+1, 3, tview.NewTableCell(width).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+		}
+	}
+
+	// save saves the given columns in the rcloneui config and renders the view and the table of the overlay again.
+	save := func(columns []config.Column) {
+		v.options.Config.SetColumns(v.options.Pane, columns)
+
+		if err := v.options.Config.Save(); err != nil {
+			v.showText("Error", fmt.Sprintf("Could not save columns: %s", tview.Escape(err.Error())))
+			return
+		}
+
+		if v.remote == "" {
+			v.renderRemotes(localPath)
+		} else {
+			v.renderRows()
+		}
+
+		render()
+	}
+
+	render()
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			v.hideOverlay()
+			return nil
+		}
+
+		row, _ := table.GetSelection()
+		columns, visible := rows()
+		if row < 1 || row-1 >= len(columns) {
+			return event
+		}
+
+		i := row - 1
+
+		// The name column is always the first column, so that it can not be hidden or moved.
+		switch {
+		case event.Key() == tcell.KeyEnter && i > 0:
+			if i < visible {
+				save(append(append([]config.Column{}, columns[:i]...), columns[i+1:visible]...))
+			} else {
+				save(append(append([]config.Column{}, columns[:visible]...), columns[i]))
+				table.Select(visible+1, 0)
+			}
+
+		case event.Rune() == 'K' && i > 1 && i < visible:
+			columns[i-1], columns[i] = columns[i], columns[i-1]
+			save(columns[:visible])
+			table.Select(row-1, 0)
+
+		case event.Rune() == 'J' && i > 0 && i < visible-1:
+			columns[i+1], columns[i] = columns[i], columns[i+1]
+			save(columns[:visible])
+			table.Select(row+1, 0)
+
+		case event.Rune() == '+' && i < visible:
+			columns[i].Expansion++
+			save(columns[:visible])
+
+		case event.Rune() == '-' && i < visible && columns[i].Expansion > 1:
+			columns[i].Expansion--
+			save(columns[:visible])
+
+		case event.Rune() == 'w' && i < visible:
+			v.showInput("Width (0 for no limit): ", strconv.Itoa(columns[i].Width), nil, func(text string) {
+				width, err := strconv.Atoi(strings.TrimSpace(text))
+				if err == nil && width >= 0 {
+					columns[i].Width = width
+					save(columns[:visible])
+				}
+
+				v.showOverlay(table)
+				table.Select(row, 0)
+			})
+
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	v.showOverlay(table)
+}
@@ -0,0 +1,39 @@
+package view
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+)
+
+const (
+	// dryRunMessage is contained in all messages, which are logged by rclone when an action is skipped because of the
+	// dry-run config.
+	dryRunMessage = "as --dry-run is set"
+)
+
+// dryRun runs the given function with rclone's dry-run config. All messages which are logged by rclone for skipped
+// actions while the function runs are collected via the given logger and returned, so that we can show the user what
+// would have happened. Only the function runs with the dry-run config, so that messages from other goroutines (e.g.
+// the automatic refresh) are ignored.
+func dryRun(logger *Logger, fn func(ctx context.Context) error) ([]string, error) {
+	var messages []string
+
+	stop := logger.collect(func(level fs.LogLevel, text string) {
+		if strings.Contains(text, dryRunMessage) {
+			messages = append(messages, text)
+		}
+	})
+
+	ctx, ci := fs.AddConfig(context.Background())
+	ci.DryRun = true
+
+	err := fn(ctx)
+
+	// The messages must only be read after the collector was stopped, because they are written while the logger is
+	// locked.
+	stop()
+
+	return messages, err
+}
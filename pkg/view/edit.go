@@ -0,0 +1,163 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/hash"
+	"github.com/rclone/rclone/fs/operations"
+	"github.com/rivo/tview"
+)
+
+// editorCommand returns the command which is used to edit files. Like most terminal programs we use the $VISUAL and
+// $EDITOR environment variables and fall back to vi if both are not set.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if command := strings.Fields(os.Getenv(env)); len(command) > 0 {
+			return command
+		}
+	}
+
+	return []string{"vi"}
+}
+
+// runCommand runs the given command with the given file as last argument in the users terminal. Therefore the
+// application is suspended until the command returns.
+func (v *View) runCommand(command []string, file string) error {
+	var err error
+
+	v.app.Suspend(func() {
+		cmd := exec.Command(command[0], append(command[1:], file)...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		err = cmd.Run()
+	})
+
+	return err
+}
+
+// edit downloads the given object into a temporary directory and opens it in the users editor. When the editor is
+// closed and the content of the file was changed, the file is uploaded again. Before the file is uploaded we check if
+// the object was changed or deleted in the meantime and ask the user if the object should be overwritten.
+// When the upload fails or the user does not want to overwrite the object, the temporary directory is kept and its path
+// is shown, so that the edits are not lost.
+func (v *View) edit(o fs.Object) {
+	ctx := context.Background()
+
+	f, err := newFs(ctx, fsPath(v.remote, v.remotePath))
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(v.remote, v.remotePath), err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "rcloneui-")
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not create temporary directory: %#v", err)
+	}
+
+	// The temporary directory is removed after the file was uploaded, so that we do not use the Fs cache for it.
+	ftmp, err := fs.NewFs(ctx, tmpDir)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		v.app.Stop()
+		log.Fatalf("Could not create new fs object for \"%s\": %#v", tmpDir, err)
+	}
+
+	filename := path.Base(o.Remote())
+
+	downloaded, err := operations.Copy(ctx, ftmp, nil, filename, o)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		v.app.Stop()
+		log.Fatalf("Could not download file: %#v", err)
+	}
+
+	// To check if the file was changed, we compare the MD5 hash of the downloaded file with the MD5 hash of the edited
+	// file. Therefore we have to compute the hash before the file is opened in the editor.
+	before, _ := downloaded.Hash(ctx, hash.MD5)
+
+	err = v.runCommand(editorCommand(), filepath.Join(tmpDir, filename))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		v.showText("Error", fmt.Sprintf("Could not run editor: %s", tview.Escape(err.Error())))
+		return
+	}
+
+	// We have to get a new object for the edited file, because the local backend caches the hashes.
+	edited, err := ftmp.NewObject(ctx, filename)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		v.app.Stop()
+		log.Fatalf("Could not get edited file: %#v", err)
+	}
+
+	after, _ := edited.Hash(ctx, hash.MD5)
+	if before == after {
+		os.RemoveAll(tmpDir)
+		return
+	}
+
+	current, err := f.NewObject(ctx, o.Remote())
+	if err != nil && err != fs.ErrorObjectNotFound {
+		os.RemoveAll(tmpDir)
+		v.app.Stop()
+		log.Fatalf("Could not get file for upload: %#v", err)
+	}
+
+	// keep shows the path of the edited file, which was not uploaded, so that the user can recover the edits.
+	keep := func(title, text string) {
+		v.showText(title, fmt.Sprintf("%s\n\nThe edited file was kept at [blue]%s[white].", text, tview.Escape(filepath.Join(tmpDir, filename))))
+	}
+
+	upload := func() {
+		err := v.mutate(auditEntry{Action: "upload", Source: filepath.Join(tmpDir, filename), Destination: fsPath(v.remote, append(append([]string{}, v.remotePath...), o.Remote()))}, func(ctx context.Context) error {
+			// We already know from the MD5 hashes that the file was changed, so that the upload must never be skipped as
+			// unchanged, e.g. on remotes without modification times when the size of the file is the same. The
+			// IgnoreTimes config forces rclone to always transfer the file.
+			ctx, ci := fs.AddConfig(ctx)
+			ci.IgnoreTimes = true
+
+			_, err := operations.Copy(ctx, f, current, o.Remote(), edited)
+			return err
+		})
+
+		v.renderEntries(v.app)
+
+		if err != nil {
+			keep("Error", fmt.Sprintf("Could not upload %s: %s", tview.Escape(o.Remote()), tview.Escape(err.Error())))
+			return
+		}
+
+		os.RemoveAll(tmpDir)
+	}
+
+	if current == nil {
+		v.showConfirm(fmt.Sprintf("The file %s was deleted while it was edited. Do you want to upload it again?", o.Remote()), func(confirmed bool) {
+			if confirmed {
+				upload()
+			} else {
+				keep("Edit", fmt.Sprintf("The file %s was not uploaded.", tview.Escape(o.Remote())))
+			}
+		})
+	} else if current.Size() != o.Size() || !current.ModTime(ctx).Equal(o.ModTime(ctx)) {
+		v.showConfirm(fmt.Sprintf("The file %s was changed while it was edited. Do you want to overwrite it?", o.Remote()), func(confirmed bool) {
+			if confirmed {
+				upload()
+			} else {
+				keep("Edit", fmt.Sprintf("The file %s was not uploaded.", tview.Escape(o.Remote())))
+			}
+		})
+	} else {
+		upload()
+	}
+}
@@ -0,0 +1,256 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/filter"
+	"github.com/rclone/rclone/fs/walk"
+	"github.com/rivo/tview"
+)
+
+// findQuery contains the conditions, which must be fulfilled by a file/folder to be returned by the find command. The
+// size conditions are only fulfilled by files. A value of -1 for the size and 0 for the age means that the condition
+// is not set.
+type findQuery struct {
+	patterns []*regexp.Regexp
+	minSize  int64
+	maxSize  int64
+	minAge   time.Duration
+	maxAge   time.Duration
+}
+
+// parseFindQuery parses the query for the find command. The query consists of terms separated by spaces, where all
+// terms must match:
+//   - "re:<regex>" matches the name against the regular expression
+//   - "size:>10M" and "size:<10M" match files larger or smaller than the given size
+//   - "age:>7d" and "age:<7d" match files/folders older or newer than the given age
+//   - all other terms are globs, which are matched against the name (e.g. "*.txt")
+func parseFindQuery(query string) (*findQuery, error) {
+	q := &findQuery{nil, -1, -1, 0, 0}
+
+	for _, term := range strings.Fields(query) {
+		switch {
+		case strings.HasPrefix(term, "re:"):
+			pattern, err := regexp.Compile(strings.TrimPrefix(term, "re:"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %w", term, err)
+			}
+			q.patterns = append(q.patterns, pattern)
+
+		case strings.HasPrefix(term, "size:>") || strings.HasPrefix(term, "size:<"):
+			var size fs.SizeSuffix
+			if err := size.Set(term[6:]); err != nil {
+				return nil, fmt.Errorf("invalid size %q: %w", term, err)
+			}
+			if term[5] == '>' {
+				q.minSize = int64(size)
+			} else {
+				q.maxSize = int64(size)
+			}
+
+		case strings.HasPrefix(term, "age:>") || strings.HasPrefix(term, "age:<"):
+			age, err := fs.ParseDuration(term[5:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid age %q: %w", term, err)
+			}
+			if term[4] == '>' {
+				q.minAge = age
+			} else {
+				q.maxAge = age
+			}
+
+		default:
+			pattern, err := filter.GlobStringToRegexp(term, true, false)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob %q: %w", term, err)
+			}
+			q.patterns = append(q.patterns, pattern)
+		}
+	}
+
+	return q, nil
+}
+
+// match returns true when the given file/folder fulfills all conditions of the query.
+func (q *findQuery) match(ctx context.Context, entry fs.DirEntry) bool {
+	name := entry.Remote()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	for _, pattern := range q.patterns {
+		if !pattern.MatchString(name) {
+			return false
+		}
+	}
+
+	if q.minSize >= 0 || q.maxSize >= 0 {
+		if _, ok := entry.(fs.Object); !ok {
+			return false
+		}
+		if (q.minSize >= 0 && entry.Size() <= q.minSize) || (q.maxSize >= 0 && entry.Size() >= q.maxSize) {
+			return false
+		}
+	}
+
+	if q.minAge > 0 || q.maxAge > 0 {
+		age := time.Since(entry.ModTime(ctx))
+		if (q.minAge > 0 && age <= q.minAge) || (q.maxAge > 0 && age >= q.maxAge) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// showFindInput renders the input for the query of the find command. When the query is valid, the results of the find
+// command are shown.
+func (v *View) showFindInput() {
+	v.showInput("Find: ", "", nil, func(text string) {
+		q, err := parseFindQuery(text)
+		if err != nil {
+			v.showText("Error", tview.Escape(err.Error()))
+			return
+		}
+
+		v.showFind(text, q)
+	})
+}
+
+// showFind walks through the current folder and all subfolders and renders all files/folders which match the given
+// query in an overlay. The current folder is listed in the background via walk.ListR, which uses the fast-list feature
+// of the remote when it is supported, so that the results are added to the table while they are found.
+// The "enter" key jumps to the folder of the selected result, the "c" key selects the result for copying and the "d"
+// key deletes it. The listing is canceled when the overlay is closed.
+func (v *View) showFind(query string, q *findQuery) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	remote := v.remote
+	remotePath := append([]string{}, v.remotePath...)
+
+	var results fs.DirEntries
+
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false).SetBorders(false)
+	table.SetBorder(true).SetTitle(fmt.Sprintf(" Find: %s (searching) ", tview.Escape(query))).SetBorderColor(tcell.ColorBlue)
+
+	render := func() {
+		table.Clear()
+		table.SetCell(0, 0, tview.NewTableCell("PATH").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(5).SetSelectable(false))
+		table.SetCell(0, 1, tview.NewTableCell("SIZE").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(1).SetSelectable(false))
+		table.SetCell(0, 2, tview.NewTableCell("DATE").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(2).SetSelectable(false))
+
+		for i, entry := range results {
+			table.SetCell(i+1, 0, tview.NewTableCell(entry.Remote()).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			table.SetCell(i+1, 1, tview.NewTableCell(fmt.Sprintf("%d", entry.Size())).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			table.SetCell(i+1, 2, tview.NewTableCell(v.options.TimeFormat.format(entry.ModTime(ctx))).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+		}
+	}
+
+	render()
+
+	f, err := newFs(ctx, fsPath(remote, remotePath))
+	if err != nil {
+		cancel()
+		v.showText("Error", fmt.Sprintf("Could not open [blue]%s[white]: %s", tview.Escape(fsPath(remote, remotePath)), tview.Escape(err.Error())))
+		return
+	}
+
+	go func() {
+		err := walk.ListR(filter.ReplaceConfig(ctx, v.remoteFilter), f, "", false, -1, walk.ListAll, func(entries fs.DirEntries) error {
+			var matches fs.DirEntries
+			for _, entry := range entries {
+				if q.match(ctx, entry) {
+					matches = append(matches, entry)
+				}
+			}
+
+			if len(matches) > 0 {
+				v.app.QueueUpdateDraw(func() {
+					results = append(results, matches...)
+					render()
+				})
+			}
+
+			return ctx.Err()
+		})
+
+		// When the listing was canceled the overlay was already closed, so that we do not have to update the title.
+		if ctx.Err() != nil {
+			return
+		}
+
+		v.app.QueueUpdateDraw(func() {
+			if err != nil {
+				table.SetTitle(fmt.Sprintf(" Find: %s (error: %s) ", tview.Escape(query), tview.Escape(err.Error())))
+			} else {
+				table.SetTitle(fmt.Sprintf(" Find: %s (%d results) ", tview.Escape(query), len(results)))
+			}
+		})
+	}()
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			cancel()
+			v.hideOverlay()
+			return nil
+		}
+
+		row, _ := table.GetSelection()
+		if row < 1 || row-1 >= len(results) {
+			return event
+		}
+
+		entry := results[row-1]
+		path := append(append([]string{}, remotePath...), strings.Split(entry.Remote(), "/")...)
+
+		if event.Key() == tcell.KeyEnter {
+			cancel()
+			v.hideOverlay()
+
+			parentPath, name := fsPathFilename(path)
+			v.jump(fsPath(remote, parentPath))
+			v.selectName(name)
+			return nil
+		}
+
+		if event.Rune() == 'c' {
+			v.status.SetSelect(remote, path, "copy")
+			return nil
+		}
+
+		// The find overlay is replaced, when the remote is write protected, the deletion failed or the results of the
+		// deletion in the dry-run mode are shown. In these cases the overlay is not shown again, so that we have to
+		// cancel the listing.
+		if event.Rune() == 'd' {
+			if !v.checkWritable(remote) {
+				cancel()
+				return nil
+			}
+
+			v.confirmDelete(remote, path, func(deleted bool, err error) {
+				if err != nil || (deleted && v.options.Config.DryRun) {
+					cancel()
+					return
+				}
+
+				if deleted {
+					results = append(results[:row-1], results[row:]...)
+					render()
+				}
+
+				v.showOverlay(table)
+			})
+			return nil
+		}
+
+		return event
+	})
+
+	v.showOverlay(table)
+}
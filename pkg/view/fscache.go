@@ -0,0 +1,47 @@
+package view
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/cache"
+	"github.com/rclone/rclone/fs/config"
+)
+
+var (
+	// fsConfig is the rclone configuration, which was used to create the cached Fs objects. It is used to detect
+	// changes of the rclone config file.
+	fsConfig   string
+	fsConfigMu sync.Mutex
+)
+
+// newFs returns the Fs for the given path (e.g. "remote:path"). The Fs is taken from rclone's Fs cache, so that the
+// clients and connections of a remote are reused and the remote must not be initialized again for each action.
+// rclone reloads the config file automatically when it was changed. In this case we remove all Fs objects from the
+// cache, so that the new configuration is used. We can not only remove the Fs objects of the changed remote, because
+// remotes like crypt or alias are cached with the name of the wrapped remote.
+// It returns fs.ErrorIsFile together with the Fs of the parent folder, when the path points to a file. Unlike fs.NewFs
+// the cache remembers forever if a path is a file or a folder, so that the cache must be cleared via clearFs after a
+// remote was modified.
+func newFs(ctx context.Context, path string) (fs.Fs, error) {
+	cfg, err := config.LoadedData().Serialize()
+	if err == nil {
+		fsConfigMu.Lock()
+		if cfg != fsConfig {
+			cache.Clear()
+			fsConfig = cfg
+		}
+		fsConfigMu.Unlock()
+	}
+
+	return cache.Get(ctx, path)
+}
+
+// clearFs removes all Fs objects from rclone's Fs cache. It must be called after a remote was modified, because a
+// deleted file can be replaced by a folder with the same name (and the other way around), which would return the wrong
+// Fs or error from the cache. We can not only remove the Fs objects of the modified remote, because local paths are
+// cached without a remote name and remotes like crypt or alias are cached with the name of the wrapped remote.
+func clearFs() {
+	cache.Clear()
+}
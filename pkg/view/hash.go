@@ -0,0 +1,84 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/hash"
+	"github.com/rclone/rclone/fs/operations"
+	"github.com/rivo/tview"
+)
+
+// hashFilename returns the name of the file, which is used to export the md5sum compatible list of hashes for the given
+// remote and path. The name is the last folder of the path or the name of the remote if the path is empty.
+func hashFilename(remote string, path []string) string {
+	if len(path) == 0 || path[len(path)-1] == "" {
+		return fmt.Sprintf("%s.md5", remote)
+	}
+
+	return fmt.Sprintf("%s.md5", path[len(path)-1])
+}
+
+// writeHashes computes all hashes which are supported by the remote of the given object and writes them to the given
+// builder. When a hash can not be computed we write the returned error instead of the hash, because some backends only
+// support a hash for some of their objects.
+func writeHashes(text *strings.Builder, o fs.Object) {
+	hashes := o.Fs().Hashes().Array()
+	if len(hashes) == 0 {
+		text.WriteString("The remote does not support any hashes.\n")
+	}
+
+	for _, ht := range hashes {
+		sum, err := operations.HashSum(context.Background(), ht, false, false, o)
+		if err != nil {
+			sum = err.Error()
+		}
+
+		fmt.Fprintf(text, "[blue]%-12s[white] %s\n", ht.String(), tview.Escape(sum))
+	}
+}
+
+// showHashes renders all hashes of the given object in an overlay.
+func (v *View) showHashes(o fs.Object) {
+	var text strings.Builder
+	writeHashes(&text, o)
+
+	v.showText(fmt.Sprintf("Hashes: %s", o.Remote()), text.String())
+}
+
+// exportHashes writes an md5sum compatible file for all objects in the current remote and path into the users local
+// directory. If the remote does not support MD5 hashes the objects are downloaded to compute the hashes.
+// Because the hash file is written to the users local directory, the export is handled like all other actions which
+// modify a remote, so that it is recorded in the audit log and skipped in the dry-run mode.
+func (v *View) exportHashes(localPath []string) {
+	f, err := newFs(context.Background(), fsPath(v.remote, v.remotePath))
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(v.remote, v.remotePath), err)
+	}
+
+	filename := fsPath(Local, append(append([]string{}, localPath...), hashFilename(v.remote, v.remotePath)))
+
+	err = v.mutate(auditEntry{Action: "export hashes", Source: fsPath(v.remote, v.remotePath), Destination: filename}, func(ctx context.Context) error {
+		if operations.SkipDestructive(ctx, filename, "export hashes") {
+			return nil
+		}
+
+		file, err := os.Create(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		return operations.HashLister(ctx, hash.MD5, false, !f.Hashes().Contains(hash.MD5), f, file)
+	})
+	if err != nil || v.options.Config.DryRun {
+		return
+	}
+
+	v.showText("Hashes", fmt.Sprintf("Exported MD5 hashes for [blue]%s[white] to [blue]%s[white].", tview.Escape(fsPath(v.remote, v.remotePath)), tview.Escape(filename)))
+}
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rivo/tview"
+)
+
+// isHidden returns true when the given file/folder is hidden, which is the case when the name starts with a ".".
+func isHidden(entry fs.DirEntry) bool {
+	return strings.HasPrefix(entryName(entry), ".")
+}
+
+// hideHidden returns true when hidden files/folders should not be shown in the view. The setting is saved per view in
+// the rcloneui config.
+func (v *View) hideHidden() bool {
+	return v.options.Pane < len(v.options.Config.Panes) && v.options.Config.Panes[v.options.Pane].HideHidden
+}
+
+// visibleEntries returns the given entries without the hidden files/folders, when they should not be shown in the
+// view. The entries can be shared with the listing cache, so that a new slice is returned instead of modifying the
+// given entries.
+func (v *View) visibleEntries(entries fs.DirEntries) fs.DirEntries {
+	if !v.hideHidden() {
+		return entries
+	}
+
+	var visible fs.DirEntries
+	for _, entry := range entries {
+		if !isHidden(entry) {
+			visible = append(visible, entry)
+		}
+	}
+
+	return visible
+}
+
+// toggleHidden shows or hides the hidden files/folders in the view and saves the setting in the rcloneui config.
+func (v *View) toggleHidden(app *tview.Application) {
+	v.options.Config.SetHideHidden(v.options.Pane, !v.hideHidden())
+
+	if err := v.options.Config.Save(); err != nil {
+		v.showText("Error", fmt.Sprintf("Could not save config: %s", tview.Escape(err.Error())))
+	}
+
+	if v.remote == "" {
+		v.renderHeader()
+	} else {
+		v.keepSelection(func() {
+			v.renderEntries(app)
+		})
+	}
+}
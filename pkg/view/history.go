@@ -0,0 +1,103 @@
+package view
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+// historyEntry is a visited location in the navigation history of a view. Besides the remote and path we also save the
+// selected row and the scroll offset, so that they can be restored when the user navigates back to the location. An
+// empty remote is used for the remotes table.
+type historyEntry struct {
+	remote string
+	path   []string
+	row    int
+	offset int
+}
+
+// equal returns true when the history entry points to the given remote and path.
+func (e historyEntry) equal(remote string, path []string) bool {
+	if e.remote != remote || len(e.path) != len(path) {
+		return false
+	}
+
+	for i := range path {
+		if e.path[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// saveHistoryPosition saves the selected row and scroll offset of the table in the current history entry. This must be
+// called before the table is cleared to render another location. While a history entry is restored, the position is
+// not saved, because the table still shows the previous location.
+func (v *View) saveHistoryPosition() {
+	if v.historyIndex < 0 || v.historyLocked {
+		return
+	}
+
+	v.history[v.historyIndex].row, _ = v.GetSelection()
+	v.history[v.historyIndex].offset, _ = v.GetOffset()
+}
+
+// recordHistory adds the current location of the view to the navigation history. It is called each time a location is
+// rendered, before the table is cleared. When the location was just rendered again (e.g. after a file was pasted), no
+// new entry is added. When a new location is visited after the user navigated back, all entries after the current one
+// are removed.
+func (v *View) recordHistory() {
+	v.saveHistoryPosition()
+
+	if v.historyIndex >= 0 && v.history[v.historyIndex].equal(v.remote, v.remotePath) {
+		return
+	}
+
+	v.history = append(v.history[:v.historyIndex+1], historyEntry{v.remote, append([]string{}, v.remotePath...), 1, 0})
+	v.historyIndex = len(v.history) - 1
+}
+
+// navigateHistory moves the given number of steps backward (negative) or forward (positive) in the navigation history
+// and renders the location of the history entry. Afterwards the selected row and scroll offset are restored.
+// The location could be deleted since it was visited, so that we check if it can still be listed. If this is not the
+// case, the user is informed via an overlay and the entry is removed from the history.
+func (v *View) navigateHistory(localPath []string, steps int) {
+	index := v.historyIndex + steps
+	if index < 0 || index >= len(v.history) {
+		return
+	}
+
+	entry := v.history[index]
+
+	if entry.remote != "" {
+		if err := v.checkLocation(entry.remote, entry.path); err != nil {
+			v.history = append(v.history[:index], v.history[index+1:]...)
+			if index < v.historyIndex {
+				v.historyIndex--
+			}
+
+			v.showText("Error", fmt.Sprintf("Could not open [blue]%s[white]: %s", tview.Escape(fsPath(entry.remote, entry.path)), tview.Escape(err.Error())))
+			return
+		}
+	}
+
+	// The position of the current location must be saved before we move to the new entry. While the new location is
+	// rendered the position is not saved again, because the table still shows the current location.
+	v.saveHistoryPosition()
+	v.historyIndex = index
+	v.historyLocked = true
+
+	if entry.remote == "" {
+		v.renderRemotes(localPath)
+	} else {
+		v.remote = entry.remote
+		v.remotePath = append([]string{}, entry.path...)
+		v.renderEntries(v.app)
+	}
+
+	v.historyLocked = false
+
+	v.Select(entry.row, 0)
+	v.SetOffset(entry.offset, 0)
+}
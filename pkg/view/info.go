@@ -0,0 +1,91 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/operations"
+	"github.com/rivo/tview"
+)
+
+// writeInfo writes a single line with the given name and value to the given builder. Empty values are rendered as "-".
+func writeInfo(text *strings.Builder, name, value string) {
+	if value == "" {
+		value = "-"
+	}
+
+	fmt.Fprintf(text, "[blue]%-18s[white] %s\n", name, tview.Escape(value))
+}
+
+// showInfo renders all available information for the given file/folder in an overlay. This includes the information
+// from the optional interfaces of an object (e.g. MimeTyper, IDer and GetTierer), the metadata and the hashes, so that
+// it depends on the backend which information is shown.
+func (v *View) showInfo(entry fs.DirEntry) {
+	ctx := context.Background()
+	precision := entry.Fs().Precision()
+
+	var text strings.Builder
+
+	writeInfo(&text, "Name", entry.String())
+	writeInfo(&text, "Location", fsPath(v.remote, append(append([]string{}, v.remotePath...), entry.String())))
+	writeInfo(&text, "Size", fmt.Sprintf("%d", entry.Size()))
+	writeInfo(&text, "MIME Type", fs.MimeTypeDirEntry(ctx, entry))
+
+	// Besides the modification time in the configured format, we also show the exact modification time with the
+	// precision of the remote.
+	writeInfo(&text, "Modified", v.options.TimeFormat.format(entry.ModTime(ctx)))
+
+	if precision == fs.ModTimeNotSupported {
+		writeInfo(&text, "Modified (exact)", entry.ModTime(ctx).String())
+		writeInfo(&text, "Precision", "not supported")
+	} else {
+		writeInfo(&text, "Modified (exact)", v.options.TimeFormat.formatLayout(entry.ModTime(ctx), operations.FormatForLSFPrecision(precision)))
+		writeInfo(&text, "Precision", precision.String())
+	}
+
+	switch e := entry.(type) {
+	case fs.Directory:
+		writeInfo(&text, "Type", "folder")
+		writeInfo(&text, "ID", e.ID())
+
+		if e.Items() >= 0 {
+			writeInfo(&text, "Items", fmt.Sprintf("%d", e.Items()))
+		}
+	case fs.Object:
+		writeInfo(&text, "Type", "file")
+
+		if do, ok := e.(fs.IDer); ok {
+			writeInfo(&text, "ID", do.ID())
+		}
+
+		if do, ok := e.(fs.GetTierer); ok {
+			writeInfo(&text, "Tier", do.GetTier())
+		}
+	}
+
+	metadata, err := fs.GetMetadata(ctx, entry)
+	if err != nil {
+		fmt.Fprintf(&text, "\n[red]Could not get metadata: %s[white]\n", tview.Escape(err.Error()))
+	} else if len(metadata) > 0 {
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		text.WriteString("\n")
+		for _, key := range keys {
+			writeInfo(&text, key, metadata[key])
+		}
+	}
+
+	if o, ok := entry.(fs.Object); ok {
+		text.WriteString("\n")
+		writeHashes(&text, o)
+	}
+
+	v.showText(fmt.Sprintf("Info: %s", entry.String()), text.String())
+}
@@ -0,0 +1,189 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rivo/tview"
+)
+
+// parseLocation parses the given location into a remote and path. A location can be a "remote:path" or an absolute
+// local path. All other locations are relative to the current remote and path of the view.
+func (v *View) parseLocation(location string) (string, []string) {
+	location = strings.TrimSpace(location)
+
+	// Local paths always start with an empty segment, because they are created by splitting the absolute path. The
+	// root folder is the only exception, because it needs two empty segments to be joined to "/".
+	if strings.HasPrefix(location, "/") {
+		if segments := splitPath(location); len(segments) > 0 {
+			return Local, append([]string{""}, segments...)
+		}
+
+		return Local, []string{"", ""}
+	}
+
+	if remote, path, ok := strings.Cut(location, ":"); ok {
+		return remote, splitPath(path)
+	}
+
+	return v.remote, append(append([]string{}, v.remotePath...), splitPath(location)...)
+}
+
+// splitPath splits the given remote path into its segments. Empty segments are removed, so that leading, trailing and
+// double slashes are ignored.
+func splitPath(path string) []string {
+	var segments []string
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// completeLocation returns a list of suggestions for the given location. When the location doesn't contain a colon and
+// isn't an absolute path we suggest the remotes. Otherwise we list the folders for the location and suggest all
+// folders which start with the last segment of the location. The listed folders are cached in the given map, so that
+// we do not have to list a folder for each character the user types.
+func (v *View) completeLocation(location string, cache map[string][]string) []string {
+	if location == "" {
+		return nil
+	}
+
+	var suggestions []string
+
+	if !strings.HasPrefix(location, "/") && !strings.Contains(location, ":") {
+		for _, remote := range v.remotes {
+			if remote != Local && strings.HasPrefix(remote, location) {
+				suggestions = append(suggestions, remote+":")
+			}
+		}
+
+		return suggestions
+	}
+
+	index := strings.LastIndex(location, "/")
+	if index == -1 {
+		index = strings.Index(location, ":")
+	}
+
+	prefix, partial := location[:index+1], location[index+1:]
+	remote, path := v.parseLocation(prefix)
+
+	folders, ok := cache[prefix]
+	if !ok {
+		folders = listFolders(remote, path)
+		cache[prefix] = folders
+	}
+
+	for _, folder := range folders {
+		if strings.HasPrefix(folder, partial) {
+			suggestions = append(suggestions, prefix+folder+"/")
+		}
+	}
+
+	return suggestions
+}
+
+// listFolders returns the names of all folders in the given remote and path. Errors are ignored, because they are only
+// used for the suggestions.
+func listFolders(remote string, path []string) []string {
+	ctx := context.Background()
+
+	f, err := newFs(ctx, fsPath(remote, path))
+	if err != nil {
+		return nil
+	}
+
+	entries, err := f.List(ctx, "")
+	if err != nil {
+		return nil
+	}
+
+	var folders []string
+	for _, entry := range entries {
+		if _, ok := entry.(fs.Directory); ok {
+			folders = append(folders, entry.String())
+		}
+	}
+	sort.Strings(folders)
+
+	return folders
+}
+
+// checkLocation returns an error when the folder at the given remote and path can not be listed. When the listing of
+// the folder is cached, the folder is not listed again.
+func (v *View) checkLocation(remote string, path []string) error {
+	ctx := context.Background()
+
+	if _, ok := v.options.Cache.get(fsPath(remote, path)); ok {
+		return nil
+	}
+
+	f, err := newFs(ctx, fsPath(remote, path))
+	if err != nil {
+		return err
+	}
+
+	_, err = f.List(ctx, "")
+	return err
+}
+
+// jump sets the remote and path of the view to the given location. When the location is a file, we jump to the folder
+// of the file and select the file. If the location doesn't exist the user is informed via an overlay.
+func (v *View) jump(location string) {
+	ctx := context.Background()
+
+	remote, path := v.parseLocation(location)
+	if remote == "" {
+		v.showText("Error", fmt.Sprintf("Invalid location [blue]%s[white].", tview.Escape(location)))
+		return
+	}
+
+	filename := ""
+
+	f, err := newFs(ctx, fsPath(remote, path))
+	if err == fs.ErrorIsFile {
+		path, filename = fsPathFilename(path)
+		f, err = newFs(ctx, fsPath(remote, path))
+	}
+	if err == nil {
+		_, err = f.List(ctx, "")
+	}
+	if err != nil {
+		v.showText("Error", fmt.Sprintf("Could not open [blue]%s[white]: %s", tview.Escape(location), tview.Escape(err.Error())))
+		return
+	}
+
+	v.remote = remote
+	v.remotePath = path
+	v.renderEntries(v.app)
+
+	v.Select(v.entryRow(0), 0)
+	v.selectName(filename)
+}
+
+// showLocation renders the location bar, where the user can enter a location to jump to. The location bar is
+// initialized with the current location of the view.
+func (v *View) showLocation() {
+	text := ""
+	if v.remote != "" {
+		text = fsPath(v.remote, v.remotePath)
+		if v.remote != Local && len(v.remotePath) == 0 {
+			text = v.remote + ":"
+		} else {
+			text = text + "/"
+		}
+	}
+
+	cache := make(map[string][]string)
+
+	v.showInput("Location: ", text, func(location string) []string {
+		return v.completeLocation(location, cache)
+	}, v.jump)
+}
@@ -0,0 +1,68 @@
+package view
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rclone/rclone/fs"
+)
+
+// Logger receives all log messages of rclone (e.g. for skipped symlinks). By default rclone writes its log messages to
+// stderr, which would break the rendering of the terminal ui, so that the messages are written to a log file instead.
+// When no log file is set, the messages are discarded.
+type Logger struct {
+	file      *os.File
+	collector func(level fs.LogLevel, text string)
+	mu        sync.Mutex
+}
+
+// Output writes the given log message of rclone to the log file and passes it to the current collector. It must be
+// used as rclone's log output via fs.LogOutput.
+func (l *Logger) Output(level fs.LogLevel, text string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.collector != nil {
+		l.collector(level, text)
+	}
+
+	if l.file != nil {
+		fmt.Fprintf(l.file, "%s %-6s: %s\n", time.Now().Format("2006/01/02 15:04:05"), level, text)
+	}
+}
+
+// collect calls the given function for each log message of rclone, until the returned stop function is called. The
+// function is called while the logger is locked, so that it must not log itself. Only one collector can be used at a
+// time. If the logger is nil, the function is never called.
+func (l *Logger) collect(fn func(level fs.LogLevel, text string)) func() {
+	if l == nil {
+		return func() {}
+	}
+
+	l.mu.Lock()
+	l.collector = fn
+	l.mu.Unlock()
+
+	return func() {
+		l.mu.Lock()
+		l.collector = nil
+		l.mu.Unlock()
+	}
+}
+
+// NewLogger returns a new logger, which appends all log messages of rclone to the file at the given path. If the path
+// is empty, all log messages are discarded.
+func NewLogger(path string) (*Logger, error) {
+	if path == "" {
+		return &Logger{}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Logger{file: file}, nil
+}
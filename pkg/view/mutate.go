@@ -0,0 +1,80 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rclone/rclone/fs/accounting"
+	"github.com/rivo/tview"
+)
+
+// mutate runs the given function, which modifies a remote. Each action is recorded in the audit log, when the audit
+// log is enabled. The number of bytes and the duration of the action are determined while the function runs.
+// When the dry-run mode is enabled, the function is run with rclone's dry-run config and the messages logged by rclone
+// are shown in an overlay, so that the user knows what would have happened.
+// If the function returns an error, the error is shown in an overlay and returned, so that the caller can skip all
+// following steps. Actions can fail for many reasons (e.g. missing permissions), so that we do not stop the
+// application.
+// Because the action modified a remote, the cached listings and Fs objects can be outdated, so that the listing cache
+// and rclone's Fs cache are cleared.
+func (v *View) mutate(entry auditEntry, fn func(ctx context.Context) error) error {
+	var messages []string
+	var err error
+
+	entry.Time = time.Now()
+	entry.DryRun = v.options.Config.DryRun
+	bytes := accounting.GlobalStats().GetBytes()
+
+	if entry.DryRun {
+		messages, err = dryRun(v.options.Logger, fn)
+	} else {
+		err = fn(context.Background())
+	}
+
+	v.options.Cache.clear()
+	clearFs()
+
+	entry.Duration = time.Since(entry.Time).String()
+	if entry.Bytes == 0 {
+		entry.Bytes = accounting.GlobalStats().GetBytes() - bytes
+	}
+
+	if err != nil {
+		entry.Outcome = "failure"
+		entry.Error = err.Error()
+	} else {
+		entry.Outcome = "success"
+	}
+
+	if v.options.AuditLog != nil {
+		if err := v.options.AuditLog.write(entry); err != nil {
+			v.app.Stop()
+			log.Fatalf("Could not write audit log: %#v", err)
+		}
+	}
+
+	if err != nil {
+		v.showText("Error", fmt.Sprintf("Could not %s: %s", tview.Escape(entry.description()), tview.Escape(err.Error())))
+		return err
+	}
+
+	if entry.DryRun {
+		var text strings.Builder
+		fmt.Fprintf(&text, "[blue]%s[white]\n\n", tview.Escape(entry.description()))
+
+		if len(messages) == 0 {
+			text.WriteString("Nothing to do.\n")
+		}
+
+		for _, message := range messages {
+			fmt.Fprintf(&text, "%s\n", tview.Escape(message))
+		}
+
+		v.showText("Dry-run", text.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/operations"
+	"github.com/rivo/tview"
+)
+
+// DefaultOpenCommand returns the command which is used to open files with the system handler. On macOS this is "open"
+// and on all other systems "xdg-open".
+func DefaultOpenCommand() string {
+	if runtime.GOOS == "darwin" {
+		return "open"
+	}
+
+	return "xdg-open"
+}
+
+// cacheFile downloads the given object into the cache directory of rcloneui and returns the path of the downloaded
+// file. The remote and path of the object are preserved in the cache directory, so that an object which is already
+// cached and not changed must not be downloaded again.
+func (v *View) cacheFile(o fs.Object) string {
+	ctx := context.Background()
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not get cache directory: %#v", err)
+	}
+
+	dir := filepath.Join(append([]string{cacheDir, "rcloneui", v.remote}, v.remotePath...)...)
+
+	fcache, err := newFs(ctx, dir)
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not create new fs object for \"%s\": %#v", dir, err)
+	}
+
+	// When the file was already downloaded we pass the cached file to the copy operation, so that it is only
+	// downloaded again when it was changed.
+	cached, err := fcache.NewObject(ctx, o.Remote())
+	if err != nil {
+		cached = nil
+	}
+
+	_, err = operations.Copy(ctx, fcache, cached, o.Remote(), o)
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not download file: %#v", err)
+	}
+
+	return filepath.Join(dir, o.Remote())
+}
+
+// open opens the given file/folder with the configured open command. Local files and folders are opened directly,
+// while files from a remote are downloaded to the cache directory first. Folders from a remote can not be opened.
+// If the open command is a terminal program, the application is suspended until the command returns. Otherwise the
+// command is started in the background.
+func (v *View) open(entry fs.DirEntry) {
+	var file string
+
+	if v.remote == Local {
+		file = fsPath(Local, append(append([]string{}, v.remotePath...), entry.String()))
+	} else if o, ok := entry.(fs.Object); ok {
+		file = v.cacheFile(o)
+	} else {
+		return
+	}
+
+	if v.options.OpenInTerminal {
+		err := v.runCommand(v.options.OpenCommand, file)
+		if err != nil {
+			v.showText("Error", fmt.Sprintf("Could not open file: %s", tview.Escape(err.Error())))
+		}
+		return
+	}
+
+	cmd := exec.Command(v.options.OpenCommand[0], append(v.options.OpenCommand[1:], file)...)
+
+	err := cmd.Start()
+	if err != nil {
+		v.showText("Error", fmt.Sprintf("Could not open file: %s", tview.Escape(err.Error())))
+		return
+	}
+
+	go cmd.Wait()
+}
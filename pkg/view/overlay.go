@@ -0,0 +1,109 @@
+package view
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	overlayPage = "overlay"
+)
+
+// centered wraps the given primitive into a flex layout, so that it is rendered in the center of the screen. The
+// primitive uses half of the available width and height.
+func centered(p tview.Primitive) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, 0, 2, true).
+			AddItem(nil, 0, 1, false), 0, 2, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// centeredHeight wraps the given primitive into a flex layout, so that it is rendered in the center of the screen. The
+// primitive uses half of the available width and the given height.
+func centeredHeight(p tview.Primitive, height int) tview.Primitive {
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(nil, 0, 1, false).
+			AddItem(p, height, 0, true).
+			AddItem(nil, 0, 1, false), 0, 2, true).
+		AddItem(nil, 0, 1, false)
+}
+
+// showOverlay renders the given primitive on top of the two views. Only one overlay can be shown at a time, so that an
+// existing overlay is replaced.
+func (v *View) showOverlay(p tview.Primitive) {
+	v.pages.AddPage(overlayPage, centered(p), true, true)
+	v.app.SetFocus(p)
+}
+
+// hideOverlay removes the overlay and returns the focus to the view.
+func (v *View) hideOverlay() {
+	v.pages.RemovePage(overlayPage)
+	v.app.SetFocus(v)
+}
+
+// showText renders the given text with the given title in an overlay. The overlay can be closed via the "escape" or
+// "q" key.
+func (v *View) showText(title, text string) {
+	textView := tview.NewTextView().SetDynamicColors(true).SetText(text)
+	textView.SetBorder(true).SetTitle(" " + title + " ").SetBorderColor(tcell.ColorBlue)
+	textView.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			v.hideOverlay()
+			return nil
+		}
+
+		return event
+	})
+
+	v.showOverlay(textView)
+}
+
+// showInput renders an input field with the given label and text in an overlay. The autocomplete function is used to
+// return a list of suggestions for the current text, where the "tab" key can be used to select a suggestion. When the
+// user presses the "enter" key the overlay is closed and the given function is called with the text of the input
+// field. The "escape" key closes the overlay without calling the function.
+func (v *View) showInput(label, text string, autocompleteFunc func(text string) []string, doneFunc func(text string)) {
+	input := tview.NewInputField().SetLabel(label).SetText(text).SetFieldBackgroundColor(tcell.ColorBlack)
+	input.SetBorder(true).SetBorderColor(tcell.ColorBlue)
+
+	if autocompleteFunc != nil {
+		input.SetAutocompleteFunc(autocompleteFunc)
+		input.SetAutocompletedFunc(func(text string, index int, source int) bool {
+			if source == tview.AutocompletedNavigate {
+				return false
+			}
+
+			input.SetText(text)
+			return source != tview.AutocompletedTab
+		})
+	}
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		v.hideOverlay()
+
+		if key == tcell.KeyEnter {
+			doneFunc(input.GetText())
+		}
+	})
+
+	v.pages.AddPage(overlayPage, centeredHeight(input, 3), true, true)
+	v.app.SetFocus(input)
+}
+
+// showConfirm renders a modal with the given text and a "Yes" and "No" button, where "No" is selected by default. When
+// the user selects a button or presses the "escape" key the modal is closed and the given function is called with the
+// users decision.
+func (v *View) showConfirm(text string, doneFunc func(confirmed bool)) {
+	modal := tview.NewModal().SetText(text).AddButtons([]string{"Yes", "No"}).SetFocus(1).SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		v.hideOverlay()
+		doneFunc(buttonLabel == "Yes")
+	})
+
+	v.pages.AddPage(overlayPage, modal, true, true)
+	v.app.SetFocus(modal)
+}
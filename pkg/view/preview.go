@@ -0,0 +1,185 @@
+package view
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rclone/rclone/fs"
+	"github.com/rivo/tview"
+)
+
+type Preview struct {
+	*tview.TextView
+
+	app     *tview.Application
+	pages   *tview.Pages
+	size    int64
+	visible bool
+	cancel  context.CancelFunc
+
+	toggleFunc func(visible bool)
+}
+
+// isBinary returns true when the given data can not be rendered as text. This is the case when the data contains a
+// null byte or when it isn't valid UTF-8. Because we only read the first bytes of a file, the last rune might be cut,
+// so that we ignore up to utf8.UTFMax-1 bytes at the end of the data.
+func isBinary(data []byte) bool {
+	if bytes.IndexByte(data, 0) != -1 {
+		return true
+	}
+
+	for i := 0; i < utf8.UTFMax && i <= len(data); i++ {
+		if utf8.Valid(data[:len(data)-i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatText returns the given text with a line number in front of each line.
+func formatText(data []byte) string {
+	var text strings.Builder
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	width := len(fmt.Sprintf("%d", len(lines)))
+
+	for i, line := range lines {
+		fmt.Fprintf(&text, "[blue]%*d[white] %s\n", width, i+1, tview.Escape(line))
+	}
+
+	return text.String()
+}
+
+// render renders the given object in the preview. We only read the configured number of bytes from the object, which
+// are rendered as text or as hex dump for binary data.
+// Because reading the object can take some time, this is done in a separate goroutine. A running render is canceled
+// when the selection changes, so that we do not render the content of a previously selected object.
+func (p *Preview) render(o fs.Object) {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.SetText("").ScrollToBeginning()
+
+	go func() {
+		data, err := p.read(ctx, o)
+		if ctx.Err() != nil {
+			return
+		}
+
+		var text string
+		if err != nil {
+			text = fmt.Sprintf("[red]Could not read file: %s", tview.Escape(err.Error()))
+		} else if isBinary(data) {
+			text = tview.Escape(hex.Dump(data))
+		} else {
+			text = formatText(data)
+		}
+
+		p.app.QueueUpdateDraw(func() {
+			if ctx.Err() == nil {
+				p.SetText(text).ScrollToBeginning()
+			}
+		})
+	}()
+}
+
+// read returns the first bytes of the given object. We use a range option, so that the backend only has to return the
+// requested bytes, but not all backends support this option, so that we also limit the reader.
+func (p *Preview) read(ctx context.Context, o fs.Object) ([]byte, error) {
+	in, err := o.Open(ctx, &fs.RangeOption{Start: 0, End: p.size - 1})
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	return io.ReadAll(io.LimitReader(in, p.size))
+}
+
+// clear removes the content of the preview and cancels a running render.
+func (p *Preview) clear() {
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+
+	p.SetText("")
+}
+
+// SetToggleFunc sets the function which is called when the preview is shown or hidden. The function is responsible to
+// add or remove the preview from the layout.
+func (p *Preview) SetToggleFunc(toggleFunc func(visible bool)) {
+	p.toggleFunc = toggleFunc
+}
+
+// Toggle shows or hides the preview.
+func (p *Preview) Toggle() {
+	p.visible = !p.visible
+
+	if !p.visible {
+		p.clear()
+	}
+
+	if p.toggleFunc != nil {
+		p.toggleFunc(p.visible)
+	}
+}
+
+// IsVisible returns true when the preview is shown.
+func (p *Preview) IsVisible() bool {
+	return p.visible
+}
+
+// MouseHandler returns the mouse handler of the preview. The preview can be scrolled via the mouse wheel, but it never
+// gets the focus via the mouse, because it has no key bindings. While an overlay is shown, all mouse events are
+// ignored.
+func (p *Preview) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	handler := p.TextView.MouseHandler()
+
+	return func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if p.pages.HasPage(overlayPage) {
+			return false, nil
+		}
+
+		if action == tview.MouseLeftDown {
+			return p.InRect(event.Position()), nil
+		}
+
+		return handler(action, event, setFocus)
+	}
+}
+
+// NewPreview returns the preview component, which renders the first bytes of the selected file. The size is the
+// number of bytes which should be read in k or suffix b|k|M|G. The pages are used to check if an overlay is shown.
+func NewPreview(app *tview.Application, pages *tview.Pages, size string) (*Preview, error) {
+	sizeParsed, err := parseSize(size)
+	if err != nil {
+		return nil, err
+	}
+
+	if sizeParsed <= 0 {
+		return nil, fmt.Errorf("preview size must be greater than zero")
+	}
+
+	text := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+
+	return &Preview{
+		text,
+		app,
+		pages,
+		int64(sizeParsed),
+		false,
+		nil,
+		nil,
+	}, nil
+}
@@ -0,0 +1,150 @@
+package view
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// fuzzyMatch checks if all characters of the pattern are contained in the given text in the same order, ignoring the
+// case of the characters. It returns the positions of the matched characters in the text or nil if the text does not
+// match the pattern.
+func fuzzyMatch(pattern, text string) []int {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return nil
+	}
+
+	var matches []int
+
+	for i, r := range []rune(text) {
+		if len(matches) < len(p) && unicode.ToLower(r) == unicode.ToLower(p[len(matches)]) {
+			matches = append(matches, i)
+		}
+	}
+
+	if len(matches) < len(p) {
+		return nil
+	}
+
+	return matches
+}
+
+// highlightMatches returns the given text, where the characters at the given positions are highlighted via color tags.
+// Consecutive characters with the same state are grouped, so that they can be escaped together.
+func highlightMatches(text string, matches []int) string {
+	matched := make(map[int]bool, len(matches))
+	for _, match := range matches {
+		matched[match] = true
+	}
+
+	var highlighted strings.Builder
+
+	runes := []rune(text)
+
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && matched[end] == matched[start] {
+			end++
+		}
+
+		if matched[start] {
+			highlighted.WriteString("[yellow::b]" + tview.Escape(string(runes[start:end])) + "[-::-]")
+		} else {
+			highlighted.WriteString(tview.Escape(string(runes[start:end])))
+		}
+
+		start = end
+	}
+
+	return highlighted.String()
+}
+
+// renderSearch highlights the matched characters in the name column of all entries, which match the current search.
+// It returns the rows of all matching entries.
+func (v *View) renderSearch() []int {
+	var rows []int
+
+	prefixes := v.treePrefixes()
+
+	for i, entry := range v.remoteEntries {
+		matches := fuzzyMatch(v.search, entryName(entry))
+		if matches == nil {
+			v.GetCell(v.entryRow(i), 0).SetText(prefixes[i] + entryName(entry))
+		} else {
+			v.GetCell(v.entryRow(i), 0).SetText(prefixes[i] + highlightMatches(entryName(entry), matches))
+			rows = append(rows, v.entryRow(i))
+		}
+	}
+
+	return rows
+}
+
+// clearSearch removes the current search and the highlighting of the matched characters.
+func (v *View) clearSearch() {
+	v.search = ""
+	v.renderSearch()
+}
+
+// nextMatch selects the next (forward) or previous (backward) entry, which matches the current search. When there is
+// no further match in the given direction, we start again at the beginning or the end of the list of entries. The
+// selected row itself is only a match, when the include parameter is true.
+func (v *View) nextMatch(forward, include bool) {
+	rows := v.renderSearch()
+	if len(rows) == 0 {
+		return
+	}
+
+	row, _ := v.GetSelection()
+
+	if forward {
+		for _, match := range rows {
+			if match > row || (include && match == row) {
+				v.Select(match, 0)
+				return
+			}
+		}
+
+		v.Select(rows[0], 0)
+	} else {
+		for i := len(rows) - 1; i >= 0; i-- {
+			if rows[i] < row || (include && rows[i] == row) {
+				v.Select(rows[i], 0)
+				return
+			}
+		}
+
+		v.Select(rows[len(rows)-1], 0)
+	}
+}
+
+// showSearch renders the search input at the bottom of the screen. While the user types, the first entry which matches
+// the search is selected and the matched characters are highlighted. The "enter" key closes the search input, but
+// keeps the search, so that the "n" and "N" keys can be used to select the next and previous match. The "escape" key
+// removes the search and selects the previously selected row again.
+func (v *View) showSearch() {
+	startRow, _ := v.GetSelection()
+
+	input := tview.NewInputField().SetLabel("/").SetText(v.search).SetFieldBackgroundColor(tcell.ColorBlack)
+	input.SetBorder(true).SetTitle(" Search ").SetBorderColor(tcell.ColorBlue)
+
+	input.SetChangedFunc(func(text string) {
+		v.search = text
+		v.Select(startRow, 0)
+		v.nextMatch(true, true)
+	})
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		v.hideOverlay()
+
+		if key == tcell.KeyEscape {
+			v.clearSearch()
+			v.Select(startRow, 0)
+		}
+	})
+
+	v.pages.AddPage(overlayPage, tview.NewFlex().SetDirection(tview.FlexRow).AddItem(nil, 0, 1, false).AddItem(input, 3, 0, true), true, true)
+	v.app.SetFocus(input)
+}
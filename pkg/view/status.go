@@ -4,39 +4,57 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 )
 
 type Status struct {
 	*tview.TextView
 
-	currentRemote string
-	currentPath   []string
+	pages *tview.Pages
+
+	currentRemote   string
+	currentPath     []string
+	currentReadOnly bool
 
 	selectedRemote string
 	selectedPath   []string
 
 	action string
+	dryRun bool
 }
 
 // render renders the status bar.
-// The current remote location and action are rendered in two separate boxes.
+// The current remote location and action are rendered in two separate boxes. When the current remote is write
+// protected or the dry-run mode is enabled, we render additional boxes after the location.
 func (s *Status) render() {
+	flags := ""
+	if s.currentRemote != "" && s.currentReadOnly {
+		flags = flags + "[black:red] read-only [black:black] "
+	}
+	if s.dryRun {
+		flags = flags + "[black:yellow] dry-run [black:black] "
+	}
+
 	if s.currentRemote != "" && len(s.selectedPath) > 0 {
-		s.SetText(fmt.Sprintf("[black:blue] %s:%s [black:black] [black:blue] %s %s:%s ", s.currentRemote, strings.Join(s.currentPath, "/"), s.action, s.selectedRemote, strings.Join(s.selectedPath, "/")))
+		s.SetText(fmt.Sprintf("[black:blue] %s:%s [black:black] %s[black:blue] %s %s:%s ", s.currentRemote, strings.Join(s.currentPath, "/"), flags, s.action, s.selectedRemote, strings.Join(s.selectedPath, "/")))
 	} else if s.currentRemote != "" {
-		s.SetText(fmt.Sprintf("[black:blue] %s:%s [black:black] [black:blue] - ", s.currentRemote, strings.Join(s.currentPath, "/")))
+		s.SetText(fmt.Sprintf("[black:blue] %s:%s [black:black] %s[black:blue] - ", s.currentRemote, strings.Join(s.currentPath, "/"), flags))
 	} else if len(s.selectedPath) > 0 {
-		s.SetText(fmt.Sprintf("[black:blue] - [black:black] [black:blue] %s %s:%s ", s.action, s.selectedRemote, strings.Join(s.selectedPath, "/")))
+		s.SetText(fmt.Sprintf("[black:blue] - [black:black] %s[black:blue] %s %s:%s ", flags, s.action, s.selectedRemote, strings.Join(s.selectedPath, "/")))
+	} else if flags != "" {
+		s.SetText(fmt.Sprintf("[black:blue] - [black:black] %s", flags))
 	} else {
 		s.SetText("")
 	}
 }
 
-// SetLocation is used to set the current location, which contains the remote and path.
-func (s *Status) SetLocation(currentRemote string, currentPath []string) {
+// SetLocation is used to set the current location, which contains the remote and path and if the remote is write
+// protected.
+func (s *Status) SetLocation(currentRemote string, currentPath []string, currentReadOnly bool) {
 	s.currentRemote = currentRemote
 	s.currentPath = currentPath
+	s.currentReadOnly = currentReadOnly
 
 	s.render()
 }
@@ -51,6 +69,13 @@ func (s *Status) SetSelect(selectedRemote string, selectedPath []string, action
 	s.render()
 }
 
+// SetDryRun is used to show if the dry-run mode is enabled.
+func (s *Status) SetDryRun(dryRun bool) {
+	s.dryRun = dryRun
+
+	s.render()
+}
+
 // GetSelectedRemote returns the selected remote.
 func (s *Status) GetSelectedRemote() string {
 	return s.selectedRemote
@@ -66,19 +91,40 @@ func (s *Status) GetAction() string {
 	return s.action
 }
 
+// MouseHandler returns the mouse handler of the status bar. The status bar has no key bindings, so that it never gets
+// the focus via the mouse. While an overlay is shown, all mouse events are ignored.
+func (s *Status) MouseHandler() func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+	handler := s.TextView.MouseHandler()
+
+	return func(action tview.MouseAction, event *tcell.EventMouse, setFocus func(p tview.Primitive)) (consumed bool, capture tview.Primitive) {
+		if s.pages.HasPage(overlayPage) {
+			return false, nil
+		}
+
+		if action == tview.MouseLeftDown {
+			return s.InRect(event.Position()), nil
+		}
+
+		return handler(action, event, setFocus)
+	}
+}
+
 // NewStatus returns the status bar component. We display the current remote, path and selection and action in the
-// status bar.
-func NewStatus(app *tview.Application) *Status {
+// status bar. The pages are used to check if an overlay is shown.
+func NewStatus(app *tview.Application, pages *tview.Pages) *Status {
 	text := tview.NewTextView().SetDynamicColors(true).SetRegions(true).SetChangedFunc(func() {
 		app.Draw()
 	}).SetTextAlign(tview.AlignLeft)
 
 	return &Status{
 		text,
+		pages,
 		"",
 		nil,
+		false,
 		"",
 		nil,
 		"",
+		false,
 	}
 }
@@ -0,0 +1,121 @@
+package view
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultTimeLayout is the default layout, which is used to format dates.
+	DefaultTimeLayout = "2006-01-02 15:04:05"
+)
+
+// TimeFormat is used to format all dates, which are shown to the user, e.g. the modification time of a file or the
+// time of an entry in the audit log. Dates are formatted with the configured layout in the local time zone or in UTC,
+// or relative to the current time (e.g. "3h ago"). The time format is shared between both views, so that the user can
+// switch the mode for both views at once.
+type TimeFormat struct {
+	layout   string
+	utc      bool
+	relative bool
+}
+
+// format returns the given time formatted with the configured layout and time zone or relative to the current time. If
+// the time format is nil, the default layout in the local time zone is used.
+func (f *TimeFormat) format(t time.Time) string {
+	if f == nil {
+		return t.Local().Format(DefaultTimeLayout)
+	}
+
+	if f.relative {
+		return relativeTime(t, time.Now())
+	}
+
+	return f.formatLayout(t, f.layout)
+}
+
+// formatLayout returns the given time formatted with the given layout in the configured time zone. It is used when the
+// layout depends on the context, e.g. the precision of the modification time in the info overlay.
+func (f *TimeFormat) formatLayout(t time.Time, layout string) string {
+	if f != nil && f.utc {
+		return t.UTC().Format(layout)
+	}
+
+	return t.Local().Format(layout)
+}
+
+// mode returns a short description of the mode, which is shown in the header of date columns. For the default mode
+// (local time zone) an empty string is returned.
+func (f *TimeFormat) mode() string {
+	if f == nil {
+		return ""
+	}
+
+	if f.relative {
+		return "relative"
+	}
+
+	if f.utc {
+		return "UTC"
+	}
+
+	return ""
+}
+
+// toggle switches to the next mode. The modes are local time zone, UTC and relative to the current time.
+func (f *TimeFormat) toggle() {
+	if f == nil {
+		return
+	}
+
+	switch {
+	case f.relative:
+		f.utc, f.relative = false, false
+	case f.utc:
+		f.utc, f.relative = false, true
+	default:
+		f.utc = true
+	}
+}
+
+// relativeTime returns the duration between the given time and now in a human readable format, e.g. "3h ago" or
+// "in 5m" for times in the future. Only the largest unit is used, because the exact time can be shown via the info
+// overlay.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+
+	suffix := "%s ago"
+	if d < 0 {
+		d = -d
+		suffix = "in %s"
+	}
+
+	var value string
+
+	switch {
+	case d < time.Minute:
+		value = fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		value = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		value = fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		value = fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		value = fmt.Sprintf("%dmo", int(d.Hours()/24/30))
+	default:
+		value = fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+
+	return fmt.Sprintf(suffix, value)
+}
+
+// NewTimeFormat returns a new time format with the given layout. If utc is true dates are shown in UTC instead of the
+// local time zone and if relative is true dates are shown relative to the current time.
+func NewTimeFormat(layout string, utc, relative bool) *TimeFormat {
+	if layout == "" {
+		layout = DefaultTimeLayout
+	}
+
+	return &TimeFormat{layout, utc, relative}
+}
@@ -0,0 +1,469 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rclone/rclone/fs"
+	"github.com/rclone/rclone/fs/operations"
+	"github.com/rclone/rclone/fs/sync"
+	"github.com/rivo/tview"
+)
+
+const (
+	trashTimeFormat = "20060102T150405.000000000"
+)
+
+// trashItem is a file/folder which was moved into the trash. The item is stored in the folder
+// "<trash>/<time>_<path>/<name>" of the remote, where path is the escaped original path of the item. This allows us to
+// restore items without storing any additional information.
+type trashItem struct {
+	remote string
+	path   []string
+	time   time.Time
+}
+
+// id returns the name of the folder in the trash, which contains the item.
+func (i trashItem) id() string {
+	path, _ := fsPathFilename(i.path)
+	return fmt.Sprintf("%s_%s", i.time.UTC().Format(trashTimeFormat), url.PathEscape(strings.Join(path, "/")))
+}
+
+// parseTrashItem returns the trash item for the given remote, folder name in the trash and name of the file/folder.
+func parseTrashItem(remote, id, name string) (trashItem, error) {
+	timeString, pathString, ok := strings.Cut(id, "_")
+	if !ok {
+		return trashItem{}, fmt.Errorf("invalid trash item %s", id)
+	}
+
+	t, err := time.Parse(trashTimeFormat, timeString)
+	if err != nil {
+		return trashItem{}, err
+	}
+
+	path, err := url.PathUnescape(pathString)
+	if err != nil {
+		return trashItem{}, err
+	}
+
+	if path == "" {
+		return trashItem{remote, []string{name}, t}, nil
+	}
+
+	return trashItem{remote, append(strings.Split(path, "/"), name), t}, nil
+}
+
+type Trash struct {
+	path      []string
+	paths     map[string][]string
+	localPath []string
+	undoLimit int
+	deleted   []trashItem
+}
+
+// root returns the path of the trash for the given remote. When a trash path is configured for the remote it is used,
+// otherwise the global trash path is used. For the special local "remote" the trash is relative to the users home
+// directory, except the configured path is absolute. For all other remotes it is relative to the root of the remote.
+func (t *Trash) root(remote string) []string {
+	path, ok := t.paths[remote]
+	if !ok {
+		path = t.path
+	}
+
+	if remote == Local && path[0] != "" {
+		return append(append([]string{}, t.localPath...), path...)
+	}
+
+	return append([]string{}, path...)
+}
+
+// itemPath returns the path of the given item in the trash.
+func (t *Trash) itemPath(item trashItem) []string {
+	return append(t.root(item.remote), item.id(), item.path[len(item.path)-1])
+}
+
+// contains returns true when the given path is the trash folder or a file/folder within the trash of the remote.
+func (t *Trash) contains(remote string, path []string) bool {
+	root := t.root(remote)
+	if len(path) < len(root) {
+		return false
+	}
+
+	for i := range root {
+		if root[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// push adds the given item to the list of deleted items, which can be restored via the undo action. When the list
+// contains more items than the configured limit, the oldest item is removed from the list.
+func (t *Trash) push(item trashItem) {
+	t.deleted = append(t.deleted, item)
+	if len(t.deleted) > t.undoLimit {
+		t.deleted = t.deleted[len(t.deleted)-t.undoLimit:]
+	}
+}
+
+// pop removes the last deleted item from the list and returns it. If the list is empty false is returned.
+func (t *Trash) pop() (trashItem, bool) {
+	if len(t.deleted) == 0 {
+		return trashItem{}, false
+	}
+
+	item := t.deleted[len(t.deleted)-1]
+	t.deleted = t.deleted[:len(t.deleted)-1]
+
+	return item, true
+}
+
+// remove removes the given item from the list of deleted items. This is required when an item is restored or purged
+// via the trash browser, so that the undo action doesn't try to restore it again.
+func (t *Trash) remove(item trashItem) {
+	for i := range t.deleted {
+		if t.deleted[i].remote == item.remote && t.deleted[i].id() == item.id() {
+			t.deleted = append(t.deleted[:i], t.deleted[i+1:]...)
+			return
+		}
+	}
+}
+
+// NewTrash returns a new trash. The path is the folder which is used for the trash in each remote, the paths are the
+// folders which are used instead for single remotes and the undo limit is the number of deletions which can be
+// restored via the undo action.
+func NewTrash(path string, paths map[string]string, undoLimit int) (*Trash, error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("trash path can not be empty")
+	}
+
+	if undoLimit < 0 {
+		return nil, fmt.Errorf("undo limit can not be negative")
+	}
+
+	// Absolute local paths are kept with the leading empty element, so that they are not relative to the home
+	// directory.
+	remotePaths := make(map[string][]string)
+	for remote, remotePath := range paths {
+		remotePath = strings.TrimRight(filepath.ToSlash(remotePath), "/")
+		if remote != Local || !filepath.IsAbs(remotePath) {
+			remotePath = strings.TrimLeft(remotePath, "/")
+		}
+
+		if strings.Trim(remotePath, "/") == "" {
+			return nil, fmt.Errorf("trash path for %s can not be empty", remote)
+		}
+
+		remotePaths[remote] = strings.Split(remotePath, "/")
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Trash{
+		strings.Split(path, "/"),
+		remotePaths,
+		strings.Split(filepath.ToSlash(homeDir), "/"),
+		undoLimit,
+		nil,
+	}, nil
+}
+
+// move moves the file/folder from the source remote and path to the destination remote and path. The source and
+// destination path must contain the name of the file/folder.
+func move(ctx context.Context, srcRemote string, srcPath []string, dstRemote string, dstPath []string) error {
+	fsrc, err := newFs(ctx, fsPath(srcRemote, srcPath))
+	if err != nil {
+		if err != fs.ErrorIsFile {
+			return err
+		}
+
+		path, filename := fsPathFilename(srcPath)
+
+		fsrc, err := newFs(ctx, fsPath(srcRemote, path))
+		if err != nil {
+			return err
+		}
+
+		path, dstFilename := fsPathFilename(dstPath)
+
+		fdst, err := newFs(ctx, fsPath(dstRemote, path))
+		if err != nil {
+			return err
+		}
+
+		return operations.MoveFile(ctx, fdst, fsrc, dstFilename, filename)
+	}
+
+	fdst, err := newFs(ctx, fsPath(dstRemote, dstPath))
+	if err != nil {
+		return err
+	}
+
+	err = sync.MoveDir(ctx, fdst, fsrc, true, true)
+	if err != nil {
+		return err
+	}
+
+	// When the folder was not moved server-side, the empty source folder is still present, so that we try to remove
+	// it.
+	operations.TryRmdir(ctx, fsrc, "")
+	return nil
+}
+
+// exists returns true when a file or folder exists at the given remote and path.
+func exists(ctx context.Context, remote string, path []string) (bool, error) {
+	f, err := newFs(ctx, fsPath(remote, path))
+	if err != nil {
+		if err == fs.ErrorIsFile {
+			return true, nil
+		}
+
+		return false, err
+	}
+
+	_, err = f.List(ctx, "")
+	if err != nil {
+		if err == fs.ErrorDirNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// moveToTrash moves the given file/folder into the trash of the remote. The item is added to the list of deleted
+// items, so that it can be restored via the undo action. In the dry-run mode nothing is moved, so that we also do not
+// add the item to the list.
+func (v *View) moveToTrash(ctx context.Context, remote string, path []string) error {
+	trash := v.options.Trash
+	item := trashItem{remote, append([]string{}, path...), time.Now()}
+
+	err := move(ctx, remote, path, remote, trash.itemPath(item))
+	if err != nil {
+		return fmt.Errorf("could not move \"%s\" to trash: %w", fsPath(remote, path), err)
+	}
+
+	if !fs.GetConfig(ctx).DryRun {
+		trash.push(item)
+	}
+
+	return nil
+}
+
+// restore moves the given item from the trash back to its original location. Afterwards the empty folder of the item
+// in the trash is removed. When a file/folder was created at the original location after the item was deleted, the
+// item is not restored, so that the new file/folder is not overwritten.
+func (v *View) restore(ctx context.Context, item trashItem) error {
+	trash := v.options.Trash
+
+	found, err := exists(ctx, item.remote, item.path)
+	if err != nil {
+		return fmt.Errorf("could not check if \"%s\" exists: %w", fsPath(item.remote, item.path), err)
+	}
+	if found {
+		return fmt.Errorf("could not restore \"%s\" from trash, because it already exists", fsPath(item.remote, item.path))
+	}
+
+	err = move(ctx, item.remote, trash.itemPath(item), item.remote, item.path)
+	if err != nil {
+		return fmt.Errorf("could not restore \"%s\" from trash: %w", fsPath(item.remote, item.path), err)
+	}
+
+	if fs.GetConfig(ctx).DryRun {
+		return nil
+	}
+
+	f, err := newFs(ctx, fsPath(item.remote, trash.root(item.remote)))
+	if err == nil {
+		operations.TryRmdir(ctx, f, item.id())
+	}
+
+	trash.remove(item)
+	return nil
+}
+
+// purge removes the given item from the trash, so that it can not be restored anymore.
+func (v *View) purge(ctx context.Context, item trashItem) error {
+	trash := v.options.Trash
+
+	f, err := newFs(ctx, fsPath(item.remote, append(trash.root(item.remote), item.id())))
+	if err != nil {
+		return fmt.Errorf("could not create new fs object for \"%s\": %w", fsPath(item.remote, trash.itemPath(item)), err)
+	}
+
+	err = operations.Purge(ctx, f, "")
+	if err != nil {
+		return fmt.Errorf("could not purge \"%s\" from trash: %w", fsPath(item.remote, trash.itemPath(item)), err)
+	}
+
+	if !fs.GetConfig(ctx).DryRun {
+		trash.remove(item)
+	}
+
+	return nil
+}
+
+// undo restores the last deleted file/folder. If the remote of the file/folder is write protected, the restore fails or
+// the dry-run mode is enabled, the file/folder stays in the list of deleted items.
+func (v *View) undo() {
+	item, ok := v.options.Trash.pop()
+	if !ok {
+		return
+	}
+
+	if !v.checkWritable(item.remote) {
+		v.options.Trash.push(item)
+		return
+	}
+
+	err := v.mutate(auditEntry{Action: "restore", Source: fsPath(item.remote, v.options.Trash.itemPath(item)), Destination: fsPath(item.remote, item.path)}, func(ctx context.Context) error {
+		return v.restore(ctx, item)
+	})
+
+	if err != nil || v.options.Config.DryRun {
+		v.options.Trash.push(item)
+	}
+
+	v.renderEntries(v.app)
+}
+
+// trashItems returns all items in the trash of the given remote. The items are sorted by the time they were deleted,
+// starting with the last deleted item.
+func (v *View) trashItems(remote string) []trashItem {
+	ctx := context.Background()
+	root := v.options.Trash.root(remote)
+
+	f, err := newFs(ctx, fsPath(remote, root))
+	if err != nil {
+		v.app.Stop()
+		log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(remote, root), err)
+	}
+
+	ids, err := f.List(ctx, "")
+	if err != nil {
+		if err == fs.ErrorDirNotFound {
+			return nil
+		}
+
+		v.app.Stop()
+		log.Fatalf("Could not get entries for \"%s\": %#v", fsPath(remote, root), err)
+	}
+
+	var items []trashItem
+
+	for _, id := range ids {
+		if _, ok := id.(fs.Directory); !ok {
+			continue
+		}
+
+		entries, err := f.List(ctx, id.Remote())
+		if err != nil {
+			v.app.Stop()
+			log.Fatalf("Could not get entries for \"%s\": %#v", fsPath(remote, append(root, id.Remote())), err)
+		}
+
+		for _, entry := range entries {
+			item, err := parseTrashItem(remote, id.Remote(), strings.TrimPrefix(entry.Remote(), id.Remote()+"/"))
+			if err == nil {
+				items = append(items, item)
+			}
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].time.After(items[j].time)
+	})
+
+	return items
+}
+
+// showTrash renders all items in the trash of the current remote in an overlay. The selected item can be restored via
+// the "r" key or purged via the "d" key, when the remote is not write protected. Because a purged item can not be
+// restored anymore, the user has to confirm the purge.
+func (v *View) showTrash() {
+	remote := v.remote
+	items := v.trashItems(remote)
+
+	table := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false).SetBorders(false)
+	table.SetBorder(true).SetTitle(fmt.Sprintf(" Trash: %s ", remote)).SetBorderColor(tcell.ColorBlue)
+
+	render := func() {
+		table.Clear()
+		table.SetCell(0, 0, tview.NewTableCell("DELETED").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(1).SetSelectable(false))
+		table.SetCell(0, 1, tview.NewTableCell("LOCATION").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(4).SetSelectable(false))
+
+		for i, item := range items {
+			table.SetCell(i+1, 0, tview.NewTableCell(v.options.TimeFormat.format(item.time)).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			table.SetCell(i+1, 1, tview.NewTableCell(fsPath(item.remote, item.path)).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+		}
+	}
+
+	render()
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEscape || event.Rune() == 'q' {
+			v.hideOverlay()
+			v.renderEntries(v.app)
+			return nil
+		}
+
+		row, _ := table.GetSelection()
+		if row < 1 || row-1 >= len(items) || v.options.Config.IsProtected(remote) {
+			return event
+		}
+
+		item := items[row-1]
+
+		// In the dry-run mode the results of the action are shown in a new overlay and when the action fails the error
+		// is shown, so that we only have to update the list of items when the item was restored or purged.
+		done := func(err error) bool {
+			if err != nil || v.options.Config.DryRun {
+				return false
+			}
+
+			items = append(items[:row-1], items[row:]...)
+			render()
+			return true
+		}
+
+		if event.Rune() == 'r' {
+			done(v.mutate(auditEntry{Action: "restore", Source: fsPath(item.remote, v.options.Trash.itemPath(item)), Destination: fsPath(item.remote, item.path)}, func(ctx context.Context) error {
+				return v.restore(ctx, item)
+			}))
+		} else if event.Rune() == 'd' {
+			v.showConfirm(fmt.Sprintf("Purge %s from the trash?\n\nThis can not be undone.", fsPath(item.remote, item.path)), func(confirmed bool) {
+				if !confirmed {
+					v.showOverlay(table)
+					return
+				}
+
+				if done(v.mutate(auditEntry{Action: "purge", Source: fsPath(item.remote, v.options.Trash.itemPath(item))}, func(ctx context.Context) error {
+					return v.purge(ctx, item)
+				})) {
+					v.showOverlay(table)
+				}
+			})
+		} else {
+			return event
+		}
+
+		return nil
+	})
+
+	v.showOverlay(table)
+}
@@ -0,0 +1,195 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rclone/rclone/fs"
+	"github.com/rivo/tview"
+)
+
+// treeNode contains the tree information for an entry of a view in the tree mode. The depth is 0 for the entries of
+// the current folder and is increased by one for each level of expanded folders.
+type treeNode struct {
+	depth    int
+	expanded bool
+}
+
+// entryName returns the name of the given file/folder. In the tree mode the remote of an entry is the path relative to
+// the current folder, so that we can not use the remote directly.
+func entryName(entry fs.DirEntry) string {
+	return path.Base(entry.Remote())
+}
+
+// entryPath returns the path of the file/folder in the given row, which includes the current path of the view.
+func (v *View) entryPath(row int) []string {
+	return append(append([]string{}, v.remotePath...), strings.Split(v.remoteEntries[v.entryIndex(row)].Remote(), "/")...)
+}
+
+// listChildren returns the files/folders of the given folder. The folder is listed via the Fs of the current folder,
+// so that the remote of the children is relative to the current folder.
+func (v *View) listChildren(dir fs.DirEntry) (fs.DirEntries, error) {
+	ctx := context.Background()
+
+	f, err := newFs(ctx, fsPath(v.remote, v.remotePath))
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := v.listEntries(ctx, f, dir.Remote())
+	if err != nil {
+		return nil, err
+	}
+
+	return v.visibleEntries(entries), nil
+}
+
+// expandTree builds the tree for the entries of the current folder, where all folders which were expanded by the user
+// are expanded again. Folders which can not be listed anymore are collapsed.
+func (v *View) expandTree() {
+	entries := v.remoteEntries
+
+	v.remoteEntries = nil
+	v.tree = nil
+
+	var add func(entries fs.DirEntries, depth int)
+	add = func(entries fs.DirEntries, depth int) {
+		for _, entry := range entries {
+			v.remoteEntries = append(v.remoteEntries, entry)
+			v.tree = append(v.tree, treeNode{depth, false})
+
+			key := fsPath(v.remote, v.entryPath(v.entryRow(len(v.remoteEntries)-1)))
+			if _, ok := entry.(fs.Directory); !ok || !v.treeExpanded[key] {
+				continue
+			}
+
+			children, err := v.listChildren(entry)
+			if err != nil {
+				delete(v.treeExpanded, key)
+				continue
+			}
+
+			v.tree[len(v.tree)-1].expanded = true
+			add(children, depth+1)
+		}
+	}
+
+	add(entries, 0)
+}
+
+// toggleNode expands or collapses the folder in the given row. When a folder is expanded, its children are listed and
+// inserted after the folder. When a folder is collapsed, all its descendants are removed.
+func (v *View) toggleNode(row int) {
+	i := v.entryIndex(row)
+
+	entry, ok := v.remoteEntries[i].(fs.Directory)
+	if !ok {
+		return
+	}
+
+	key := fsPath(v.remote, v.entryPath(row))
+
+	// We always create new slices, because the entries can be shared with the listing cache.
+	var entries fs.DirEntries
+	var tree []treeNode
+
+	if v.tree[i].expanded {
+		end := i + 1
+		for end < len(v.tree) && v.tree[end].depth > v.tree[i].depth {
+			delete(v.treeExpanded, fsPath(v.remote, v.entryPath(v.entryRow(end))))
+			end++
+		}
+
+		entries = append(append(entries, v.remoteEntries[:i+1]...), v.remoteEntries[end:]...)
+		tree = append(append(tree, v.tree[:i+1]...), v.tree[end:]...)
+		tree[i].expanded = false
+		delete(v.treeExpanded, key)
+	} else {
+		children, err := v.listChildren(entry)
+		if err != nil {
+			v.showText("Error", fmt.Sprintf("Could not list [blue]%s[white]: %s", tview.Escape(key), tview.Escape(err.Error())))
+			return
+		}
+
+		entries = append(append(append(entries, v.remoteEntries[:i+1]...), children...), v.remoteEntries[i+1:]...)
+		tree = append(tree, v.tree[:i+1]...)
+		for range children {
+			tree = append(tree, treeNode{v.tree[i].depth + 1, false})
+		}
+		tree = append(tree, v.tree[i+1:]...)
+		tree[i].expanded = true
+		v.treeExpanded[key] = true
+	}
+
+	v.remoteEntries = entries
+	v.tree = tree
+
+	v.renderRows()
+	v.updatePreview()
+}
+
+// treePrefixes returns the prefix for the name of each entry. In the tree mode the prefix contains the indentation
+// guides and a marker for folders, which shows if the folder is expanded. In the flat mode all prefixes are empty.
+func (v *View) treePrefixes() []string {
+	prefixes := make([]string, len(v.remoteEntries))
+	if !v.treeMode || len(v.tree) != len(v.remoteEntries) {
+		return prefixes
+	}
+
+	// To render the guides we have to know if a node is the last child of its parent. Therefore we go through the
+	// nodes from the end and remember if we have already seen a node on the same depth.
+	last := make([]bool, len(v.tree))
+	seen := make(map[int]bool)
+
+	for i := len(v.tree) - 1; i >= 0; i-- {
+		depth := v.tree[i].depth
+		last[i] = !seen[depth]
+		seen[depth] = true
+
+		for d := range seen {
+			if d > depth {
+				delete(seen, d)
+			}
+		}
+	}
+
+	// The guides for the ancestors of a node are only rendered, when the ancestor is not the last child of its parent.
+	ancestorLast := make(map[int]bool)
+
+	for i, node := range v.tree {
+		ancestorLast[node.depth] = last[i]
+
+		var prefix strings.Builder
+		for d := 1; d < node.depth; d++ {
+			if ancestorLast[d] {
+				prefix.WriteString("   ")
+			} else {
+				prefix.WriteString("│  ")
+			}
+		}
+
+		if node.depth > 0 {
+			if last[i] {
+				prefix.WriteString("└─ ")
+			} else {
+				prefix.WriteString("├─ ")
+			}
+		}
+
+		if _, ok := v.remoteEntries[i].(fs.Directory); ok {
+			if node.expanded {
+				prefix.WriteString("▾ ")
+			} else {
+				prefix.WriteString("▸ ")
+			}
+		} else {
+			prefix.WriteString("  ")
+		}
+
+		prefixes[i] = prefix.String()
+	}
+
+	return prefixes
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rclone/rclone/fs"
@@ -12,6 +15,7 @@ import (
 	"github.com/rclone/rclone/fs/operations"
 	"github.com/rclone/rclone/fs/sync"
 	"github.com/rclone/rclone/fs/walk"
+	"github.com/ricoberger/rcloneui/pkg/config"
 	"github.com/rivo/tview"
 )
 
@@ -19,6 +23,44 @@ const (
 	Local = "local"
 )
 
+// Options contains all settings for a view, which can be changed by the user.
+type Options struct {
+	// OpenCommand is the command which is used to open files, e.g. "xdg-open". The path of the file is passed as last
+	// argument to the command.
+	OpenCommand []string
+	// OpenInTerminal must be set to true when the OpenCommand is a terminal program. The application is then suspended
+	// until the command returns.
+	OpenInTerminal bool
+	// Trash is used to move deleted files/folders into a trash folder instead of deleting them. If the trash is nil,
+	// files/folders are deleted directly.
+	Trash *Trash
+	// DeleteConfirmFiles and DeleteConfirmSize are the thresholds for the number of files and the total size of a
+	// deletion, above which the user has to confirm the deletion. A negative value disables the threshold.
+	DeleteConfirmFiles int64
+	DeleteConfirmSize  fs.SizeSuffix
+	// Config is the rcloneui configuration, which contains the read-only mode and the list of write protected
+	// remotes.
+	Config *config.Config
+	// AuditLog is used to record all actions which modify a remote. If the audit log is nil, no actions are recorded.
+	AuditLog *AuditLog
+	// RefreshInterval is the interval in which remotes which support change notifications are checked for changes. If
+	// RefreshPoll is true, the current folder of all other remotes is listed again in this interval. Local folders are
+	// always watched via filesystem notifications. An interval of 0 disables the automatic refresh for remotes.
+	RefreshInterval time.Duration
+	RefreshPoll     bool
+	// Pane is the index of the view, which is used for the view specific settings in the rcloneui config.
+	Pane int
+	// Logger receives all log messages of rclone. It is used to collect the messages of an action in the dry-run mode.
+	Logger *Logger
+	// Cache is used to cache the listings of folders. If the cache is nil, the folders are always listed again.
+	Cache *ListingCache
+	// ParentRow adds a ".." row above the files/folders of a folder, which can be selected to go up a folder.
+	ParentRow bool
+	// TimeFormat is used to format all dates. If the time format is nil, the default layout in the local time zone is
+	// used.
+	TimeFormat *TimeFormat
+}
+
 type View struct {
 	*tview.Table
 
@@ -27,70 +69,477 @@ type View struct {
 	remotePath    []string
 	remoteEntries fs.DirEntries
 	remoteFilter  *filter.Filter
-
-	status    *Status
-	otherView *View
+	search        string
+	treeMode      bool
+	tree          []treeNode
+	treeExpanded  map[string]bool
+
+	options    Options
+	app        *tview.Application
+	pages      *tview.Pages
+	status     *Status
+	preview    *Preview
+	breadcrumb *Breadcrumb
+	otherView  *View
+
+	history       []historyEntry
+	historyIndex  int
+	historyLocked bool
+
+	watchPath   string
+	watchCancel context.CancelFunc
+
+	columnsCancel context.CancelFunc
 }
 
 // renderHeader renders the header of the table.
-// The table header always contains the name, size and date of a file/folder.
+// The table header contains the configured columns, by default this is the name, size and date of a file/folder. When
+// the tree mode is enabled or hidden files are not shown, this is shown in the header of the name column and when dates
+// are not shown in the local time zone, the mode is shown in the header of the date column.
 func (v *View) renderHeader() {
-	v.SetCell(0, 0, tview.NewTableCell("NAME").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(5).SetSelectable(true))
-	v.SetCell(0, 1, tview.NewTableCell("SIZE").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(2).SetSelectable(true))
-	v.SetCell(0, 2, tview.NewTableCell("DATE").SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(2).SetSelectable(true))
+	for i, column := range v.columns() {
+		name := strings.ToUpper(column.Name)
+		if column.Name == "name" {
+			var modes []string
+			if v.treeMode {
+				modes = append(modes, "tree")
+			}
+			if v.hideHidden() {
+				modes = append(modes, "no hidden")
+			}
+
+			if len(modes) > 0 {
+				name = fmt.Sprintf("NAME (%s)", strings.Join(modes, ", "))
+			}
+		}
+		if column.Name == "date" && v.options.TimeFormat.mode() != "" {
+			name = fmt.Sprintf("DATE (%s)", v.options.TimeFormat.mode())
+		}
+
+		v.SetCell(0, i, tview.NewTableCell(name).SetTextColor(tcell.ColorWhite).SetAlign(tview.AlignLeft).SetExpansion(column.Expansion).SetMaxWidth(column.Width).SetSelectable(true))
+	}
 }
 
-// renderRemotes renders the table which shows all configured remotes.
-// Before we render the list of remotes we have to reset the selected remote, path and entries. Then we also clear the
-// current view and status. After this we can render the header and each remote as a row.
+// renderRemotes renders the table which shows all configured remotes and bookmarks.
+// Before we render the list of remotes we have to reset the selected remote, path and entries. Then we also update the
+// status and breadcrumb. After this we can render the header and each remote and bookmark as a row.
 func (v *View) renderRemotes(localPath []string) {
 	v.remote = ""
 	v.remotePath = nil
 	v.remoteEntries = nil
+	v.search = ""
+
+	v.recordHistory()
+	v.updateStatus()
+	v.breadcrumb.SetLocation("", nil)
+	v.watch()
+	v.loadSlowColumns()
+	v.renderRemoteRows()
+	v.updatePreview()
+}
 
+// renderRemoteRows renders the header and a row for each remote and bookmark. Remotes and bookmarks only have a name,
+// so that all other columns are empty.
+func (v *View) renderRemoteRows() {
 	v.Clear()
 	v.renderHeader()
-	v.status.SetLocation("", nil)
 
 	for i, remote := range v.remotes {
-		v.SetCell(i+1, 0, tview.NewTableCell(remote).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
-		v.SetCell(i+1, 1, tview.NewTableCell("").SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
-		v.SetCell(i+1, 2, tview.NewTableCell("").SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+		for j, column := range v.columns() {
+			text := ""
+			if j == 0 {
+				text = remote
+			}
+
+			v.SetCell(i+1, j, tview.NewTableCell(text).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft).SetMaxWidth(column.Width))
+		}
+	}
+
+	for i, bookmark := range v.options.Config.Bookmarks {
+		row := len(v.remotes) + i + 1
+		for j, column := range v.columns() {
+			text := ""
+			if j == 0 {
+				text = bookmark
+			}
+
+			v.SetCell(row, j, tview.NewTableCell(text).SetTextColor(tcell.ColorYellow).SetAlign(tview.AlignLeft).SetMaxWidth(column.Width))
+		}
 	}
 }
 
 // renderEntries renders the rows for all entries (files and folders) which are returned by rclone.
 // When the user selected a file we do nothing. If the user selected a folder we add this folder to the list of paths
 // and then we try to retrieve all entries for the new path and render them in the table.
+// When the entries for the path are cached, we use the cached entries instead of listing the path again.
 func (v *View) renderEntries(app *tview.Application) {
-	f, err := fs.NewFs(context.Background(), fsPath(v.remote, v.remotePath))
-	if err != nil {
-		if err == fs.ErrorIsFile {
-			return
-		} else {
+	if entries, ok := v.options.Cache.get(fsPath(v.remote, v.remotePath)); ok {
+		v.remoteEntries = entries
+	} else {
+		f, err := newFs(context.Background(), fsPath(v.remote, v.remotePath))
+		if err != nil {
+			if err == fs.ErrorIsFile {
+				return
+			} else {
+				app.Stop()
+				log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(v.remote, v.remotePath), err)
+			}
+		}
+
+		v.remoteEntries, err = v.listEntries(context.Background(), f, "")
+		if err != nil {
 			app.Stop()
-			log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(v.remote, v.remotePath), err)
+			log.Fatalf("Could not get entries for \"%s\": %#v", fsPath(v.remote, v.remotePath), err)
 		}
+
+		v.options.Cache.set(fsPath(v.remote, v.remotePath), v.remoteEntries)
 	}
 
-	err = walk.ListR(filter.ReplaceConfig(context.Background(), v.remoteFilter), f, "", false, 1, walk.ListAll, func(entries fs.DirEntries) error {
-		v.remoteEntries = entries
+	v.renderListing()
+}
+
+// listEntries returns all entries (files and folders) of the given folder in the Fs, which are matching the filter of
+// the view. The folder is relative to the root of the Fs, an empty folder lists the root.
+func (v *View) listEntries(ctx context.Context, f fs.Fs, dir string) (fs.DirEntries, error) {
+	var remoteEntries fs.DirEntries
+
+	err := walk.ListR(filter.ReplaceConfig(ctx, v.remoteFilter), f, dir, false, 1, walk.ListAll, func(entries fs.DirEntries) error {
+		remoteEntries = entries
 		return nil
 	})
-	if err != nil {
-		app.Stop()
-		log.Fatalf("Could not get entries for \"%s\": %#v", fsPath(v.remote, v.remotePath), err)
+
+	return remoteEntries, err
+}
+
+// renderListing renders the rows for the current entries of the view. Hidden files/folders are removed from the entries
+// when they should not be shown and in the tree mode the previously expanded folders are expanded again. Afterwards we
+// start watching the current path for changes, so that the entries can be refreshed automatically.
+func (v *View) renderListing() {
+	v.search = ""
+
+	v.recordHistory()
+
+	v.remoteEntries = v.visibleEntries(v.remoteEntries)
+
+	if v.treeMode {
+		v.expandTree()
+	} else {
+		v.tree = nil
 	}
 
+	v.renderRows()
+	v.updatePreview()
+	v.watch()
+}
+
+// renderRows renders a row for each entry of the view. In the tree mode the name of an entry is prefixed with the
+// indentation guides of the tree. The values of slow columns (e.g. hashes) are loaded in the background. When the
+// parent row is enabled, it is rendered above the entries.
+func (v *View) renderRows() {
 	v.Clear()
 	v.renderHeader()
-	v.status.SetLocation(v.remote, v.remotePath)
+	v.updateStatus()
+	v.breadcrumb.SetLocation(v.remote, v.remotePath)
+
+	if v.hasParentRow() {
+		v.SetCell(1, 0, tview.NewTableCell("..").SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+	}
+
+	prefixes := v.treePrefixes()
+
+	for i := range v.remoteEntries {
+		for j, column := range v.columns() {
+			value := v.columnValue(context.Background(), column.Name, v.entryRow(i))
+			if j == 0 {
+				value = prefixes[i] + value
+			}
+
+			v.SetCell(v.entryRow(i), j, tview.NewTableCell(value).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft).SetMaxWidth(column.Width))
+		}
+	}
+
+	v.loadSlowColumns()
+}
+
+// updateStatus shows the location of the view in the status bar. The status bar is shared between both views, so that
+// it is only updated when the view is focused. The other view can be rendered in the background (e.g. by the automatic
+// refresh) and must not replace the location of the focused view.
+func (v *View) updateStatus() {
+	if !v.HasFocus() {
+		return
+	}
+
+	v.status.SetLocation(v.remote, v.remotePath, v.remote != "" && v.options.Config.IsProtected(v.remote))
+}
+
+// updatePreview renders the selected file in the preview when the preview is visible. If a folder or remote is
+// selected the preview is cleared. Like the status bar, the preview is shared between both views, so that it is only
+// updated when the view is focused.
+func (v *View) updatePreview() {
+	if !v.preview.IsVisible() || !v.HasFocus() {
+		return
+	}
+
+	if o, ok := v.selectedEntry().(fs.Object); ok {
+		v.preview.render(o)
+	} else {
+		v.preview.clear()
+	}
+}
+
+// hasParentRow returns true when the ".." row is rendered above the files/folders. The row is never rendered in the
+// list of remotes.
+func (v *View) hasParentRow() bool {
+	return v.options.ParentRow && v.remote != ""
+}
+
+// entryRow returns the row of the table for the file/folder with the given index. The entries are rendered after the
+// header and the optional parent row.
+func (v *View) entryRow(i int) int {
+	if v.hasParentRow() {
+		return i + 2
+	}
+
+	return i + 1
+}
+
+// entryIndex returns the index of the file/folder for the given row of the table. This is the reverse of entryRow, so
+// that the returned index is negative for the header and parent row.
+func (v *View) entryIndex(row int) int {
+	if v.hasParentRow() {
+		return row - 2
+	}
+
+	return row - 1
+}
+
+// selectedEntry returns the file/folder for the selected row. If the header row, the parent row or a remote is
+// selected, nil is returned.
+func (v *View) selectedEntry() fs.DirEntry {
+	row, _ := v.GetSelection()
+	if v.remote == "" || v.entryIndex(row) < 0 || v.entryIndex(row) >= len(v.remoteEntries) {
+		return nil
+	}
+
+	return v.remoteEntries[v.entryIndex(row)]
+}
+
+// goUp goes up a folder. If there is no entry in the path list we go back to the remotes selection table. The folder
+// or remote we came from is selected again, so that the user does not lose the position in large folders.
+func (v *View) goUp(localPath []string) {
+	if len(v.remotePath) == 0 {
+		remote := v.remote
+		v.renderRemotes(localPath)
+		v.selectName(remote)
+	} else {
+		folder := v.remotePath[len(v.remotePath)-1]
+		v.remotePath = v.remotePath[:len(v.remotePath)-1]
+		v.renderEntries(v.app)
+		v.selectName(folder)
+	}
+}
+
+// selectName selects the row of the remote, bookmark or file/folder with the given name. For files/folders in the tree
+// mode the name is the path relative to the current folder. If there is no row with the given name the selection is
+// not changed.
+func (v *View) selectName(name string) {
+	for row := 1; row < v.GetRowCount(); row++ {
+		text := v.GetCell(row, 0).Text
+		if v.remote != "" && v.entryIndex(row) >= 0 && v.entryIndex(row) < len(v.remoteEntries) {
+			text = v.remoteEntries[v.entryIndex(row)].Remote()
+		}
+
+		if text == name {
+			v.Select(row, 0)
+			return
+		}
+	}
+}
+
+// delete deletes the file/folder at the given remote and path. When the trash is enabled, the file/folder is moved into
+// the trash of the remote instead, except the file/folder is already in the trash. Local files/folders are deleted via
+// os.RemoveAll, except in the dry-run mode, where we have to use rclone to not delete anything.
+func (v *View) delete(ctx context.Context, remote string, path []string) error {
+	if v.options.Trash != nil && !v.options.Trash.contains(remote, path) {
+		return v.moveToTrash(ctx, remote, path)
+	}
+
+	if remote == Local && !fs.GetConfig(ctx).DryRun {
+		err := os.RemoveAll(fsPath(remote, path))
+		if err != nil {
+			return fmt.Errorf("could not delete file: %w", err)
+		}
+	} else {
+		f, err := newFs(ctx, fsPath(remote, path))
+		if err != nil {
+			if err == fs.ErrorIsFile {
+				path, filename := fsPathFilename(path)
+
+				fdst, err := newFs(ctx, fsPath(remote, path))
+				if err != nil {
+					return fmt.Errorf("could not create new fsrc object: %w", err)
+				}
+
+				dst, err := fdst.NewObject(ctx, filename)
+				if err != nil {
+					return fmt.Errorf("could not get file for deletion: %w", err)
+				}
+
+				err = operations.DeleteFile(ctx, dst)
+				if err != nil {
+					return fmt.Errorf("could not delete file: %w", err)
+				}
+			} else {
+				return fmt.Errorf("could not create new fs object for \"%s\": %w", fsPath(remote, path), err)
+			}
+		} else {
+			err := operations.Delete(ctx, f)
+			if err != nil {
+				return fmt.Errorf("could not delete folder: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// confirmDelete deletes the file/folder at the given remote and path. Before the file/folder is deleted we count the
+// number of files and the total size of the deletion. When one of them is above the configured threshold, the user has
+// to confirm the deletion in a dialog, which shows the number of files and the total size.
+// The optional done function is called with the users decision, after the file/folder was deleted or the deletion was
+// canceled. When the deletion failed or the file/folder could not be counted, it is called with the error, which is
+// already shown in an overlay, so that the overlay must not be replaced by the done function.
+func (v *View) confirmDelete(remote string, path []string, doneFunc func(deleted bool, err error)) {
+	ctx := context.Background()
+
+	var files, size int64
+
+	done := func(deleted bool, err error) {
+		if doneFunc != nil {
+			doneFunc(deleted, err)
+		}
+	}
+
+	// The file/folder can be deleted or become unreadable after it was listed (e.g. by another application), so that
+	// errors are shown instead of stopping the application.
+	fail := func(text string, err error) {
+		v.showText("Error", fmt.Sprintf("%s: %s", text, tview.Escape(err.Error())))
+		done(false, err)
+	}
+
+	f, err := newFs(ctx, fsPath(remote, path))
+	if err != nil {
+		if err == fs.ErrorIsFile {
+			parentPath, filename := fsPathFilename(path)
+
+			fparent, err := newFs(ctx, fsPath(remote, parentPath))
+			if err != nil {
+				fail(fmt.Sprintf("Could not create new fs object for %s", tview.Escape(fsPath(remote, parentPath))), err)
+				return
+			}
+
+			o, err := fparent.NewObject(ctx, filename)
+			if err != nil {
+				fail(fmt.Sprintf("Could not get %s for deletion", tview.Escape(fsPath(remote, path))), err)
+				return
+			}
+
+			files, size = 1, o.Size()
+		} else {
+			fail(fmt.Sprintf("Could not create new fs object for %s", tview.Escape(fsPath(remote, path))), err)
+			return
+		}
+	} else {
+		files, size, _, err = operations.Count(ctx, f)
+		if err != nil {
+			fail(fmt.Sprintf("Could not count files of %s for deletion", tview.Escape(fsPath(remote, path))), err)
+			return
+		}
+	}
+
+	action, description := "delete", "Delete"
+	if v.options.Trash != nil && !v.options.Trash.contains(remote, path) {
+		action, description = "trash", "Move to trash"
+	}
+
+	deleteFunc := func() {
+		err := v.mutate(auditEntry{Action: action, Source: fsPath(remote, path), Bytes: size}, func(ctx context.Context) error {
+			return v.delete(ctx, remote, path)
+		})
+		v.renderEntries(v.app)
+
+		done(err == nil, err)
+	}
+
+	if (v.options.DeleteConfirmFiles < 0 || files <= v.options.DeleteConfirmFiles) && (v.options.DeleteConfirmSize < 0 || size <= int64(v.options.DeleteConfirmSize)) {
+		deleteFunc()
+		return
+	}
+
+	v.showConfirm(fmt.Sprintf("%s %s?\n\n%d files, %s", description, fsPath(remote, path), files, fs.SizeSuffix(size).ByteUnit()), func(confirmed bool) {
+		if confirmed {
+			deleteFunc()
+		} else {
+			done(false, nil)
+		}
+	})
+}
+
+// paste copies the file/folder from the selected remote and path into the current remote and path. If the selected
+// path is a file we have to remove the filename from the path, so that we can use the operations.CopyFile function to
+// copy the file. If the selected path is a folder we can use the sync.CopyDir function to copy the folder.
+func (v *View) paste(ctx context.Context, selectedRemote string, selectedPath []string) error {
+	_, err := newFs(ctx, fsPath(selectedRemote, selectedPath))
+	if err != nil {
+		if err == fs.ErrorIsFile {
+			path, filename := fsPathFilename(selectedPath)
+
+			fsrc, err := newFs(ctx, fsPath(selectedRemote, path))
+			if err != nil {
+				return fmt.Errorf("could not create new fsrc object: %w", err)
+			}
 
-	for i, entry := range v.remoteEntries {
-		v.SetCell(i+1, 0, tview.NewTableCell(entry.String()).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
-		v.SetCell(i+1, 1, tview.NewTableCell(fmt.Sprintf("%d", entry.Size())).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
-		v.SetCell(i+1, 2, tview.NewTableCell(entry.ModTime(context.Background()).Format("2006-01-02 15:04:05")).SetTextColor(tcell.ColorBlue).SetAlign(tview.AlignLeft))
+			fdst, err := newFs(ctx, fsPath(v.remote, v.remotePath))
+			if err != nil {
+				return fmt.Errorf("could not create new fdst object: %w", err)
+			}
+
+			err = operations.CopyFile(ctx, fdst, fsrc, filename, filename)
+			if err != nil {
+				return fmt.Errorf("could not copy/paste file: %w", err)
+			}
+		} else {
+			return fmt.Errorf("could not create new fs object for \"%s\": %w", fsPath(selectedRemote, selectedPath), err)
+		}
+	} else {
+		fsrc, err := newFs(ctx, fsPath(selectedRemote, selectedPath))
+		if err != nil {
+			return fmt.Errorf("could not create new fsrc object: %w", err)
+		}
+
+		fdst, err := newFs(ctx, fsPath(v.remote, append(v.remotePath, selectedPath[len(selectedPath)-1])))
+		if err != nil {
+			return fmt.Errorf("could not create new fdst object: %w", err)
+		}
+
+		err = sync.CopyDir(ctx, fdst, fsrc, true)
+		if err != nil {
+			return fmt.Errorf("could not copy/paste folder: %w", err)
+		}
 	}
+
+	return nil
+}
+
+// checkWritable returns true when the given remote can be modified. If the remote is write protected, the user is
+// informed via an overlay and false is returned.
+func (v *View) checkWritable(remote string) bool {
+	if v.options.Config.IsProtected(remote) {
+		v.showText("Read-only", fmt.Sprintf("The remote [blue]%s[white] is write protected.", tview.Escape(remote)))
+		return false
+	}
+
+	return true
 }
 
 // SetView is used to pass the other created view to this view instance. This is required so that we can switch the
@@ -100,8 +549,9 @@ func (v *View) SetView(otherView *View) {
 }
 
 // NewView returns a new view. To create a new view we have to pass the app so that we can stop the application in case
-// of an error. It also requires the status compnent, the remotes and the current directory of the user.
-func NewView(app *tview.Application, status *Status, remotes, localPath []string, remoteFilter *filter.Filter) *View {
+// of an error and the pages, which are used to render overlays on top of the views. It also requires the status and
+// preview compnents, the remotes, the current directory of the user, the filter and the options for the view.
+func NewView(app *tview.Application, pages *tview.Pages, status *Status, preview *Preview, breadcrumb *Breadcrumb, remotes, localPath []string, remoteFilter *filter.Filter, options Options) *View {
 	v := &View{
 		tview.NewTable().SetFixed(1, 0).SetSelectable(true, false).SetEvaluateAllRows(true).SetBorders(false),
 		remotes,
@@ -109,21 +559,51 @@ func NewView(app *tview.Application, status *Status, remotes, localPath []string
 		nil,
 		nil,
 		remoteFilter,
+		"",
+		false,
+		nil,
+		make(map[string]bool),
+		options,
+		app,
+		pages,
 		status,
+		preview,
+		breadcrumb,
+		nil,
+		nil,
+		-1,
+		false,
+		"",
+		nil,
 		nil,
 	}
 
 	// We always show the list of remotes first.
 	v.renderRemotes(localPath)
 
-	// The following is used to handle a slection of an table row. A row can be selected by pressing "enter". This is
-	// only used to navigate between folders. File actions are not triggered by "enter".
-	v.SetSelectedFunc(func(row int, column int) {
+	// The following is used to handle a slection of an table row. A row can be selected by pressing "enter" or by a
+	// double click. This is only used to navigate between folders. File actions are not triggered by "enter".
+	selected := func(row int, column int) {
 		// When the first row is selected we do nothing, because this is always the table header.
 		if row == 0 {
 			return
 		}
 
+		// The parent row is used to go up a folder, like the "backspace" key.
+		if v.hasParentRow() && row == 1 {
+			v.goUp(localPath)
+			return
+		}
+
+		// If no remote is set and the user selected a bookmark, which are rendered after the remotes, we jump to the
+		// location of the bookmark.
+		if v.remote == "" && row > len(remotes) {
+			if row-1-len(remotes) < len(v.options.Config.Bookmarks) {
+				v.jump(v.options.Config.Bookmarks[row-1-len(remotes)])
+			}
+			return
+		}
+
 		// If no remote is set we are in the remotes view, where the user is able to select a remote or the special
 		// local "remote", which will be the users current directory.
 		// We also have to check if the user selected the special local "remote", because we have then also set the
@@ -138,13 +618,21 @@ func NewView(app *tview.Application, status *Status, remotes, localPath []string
 		}
 
 		// If the list of entries is larger then zero we can use the current selection to select an entry by the
-		// provided row number (we have to substract 1, because of the header).
+		// provided row number (the index is returned by entryIndex, because of the header and the parent row).
 		// Before we adjust the path, we have to check if the user selected a file. If this is the case we do not modify
 		// the current path.
-		if len(v.remoteEntries) > 0 {
-			entry := v.remoteEntries[row-1]
+		// The row must be checked against the list of entries, because the selection can point to a row of the previous
+		// location for a short time after the entries were rendered.
+		// In the tree mode a selected folder is expanded or collapsed instead.
+		if v.treeMode && v.entryIndex(row) >= 0 && v.entryIndex(row) < len(v.remoteEntries) {
+			v.toggleNode(row)
+			return
+		}
 
-			_, err := fs.NewFs(context.Background(), fsPath(v.remote, append(v.remotePath, entry.String())))
+		if len(v.remoteEntries) > 0 && v.entryIndex(row) < len(v.remoteEntries) {
+			entry := v.remoteEntries[v.entryIndex(row)]
+
+			_, err := newFs(context.Background(), fsPath(v.remote, append(v.remotePath, entry.String())))
 			if err != nil {
 				if err == fs.ErrorIsFile {
 					return
@@ -158,6 +646,72 @@ func NewView(app *tview.Application, status *Status, remotes, localPath []string
 		}
 
 		v.renderEntries(app)
+	}
+
+	v.SetSelectedFunc(selected)
+
+	// The table already handles single clicks (select a row and focus the view) and the mouse wheel. A double click
+	// selects the row under the mouse like the "enter" key. While an overlay is shown, all mouse events for the view are
+	// ignored, so that the focus can not be moved away from the overlay.
+	// The mouse capture is called for all mouse events, also when the mouse is not over the view, so that we have to
+	// check the position of the double click.
+	v.SetMouseCapture(func(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+		if v.pages.HasPage(overlayPage) {
+			return action, nil
+		}
+
+		if action == tview.MouseLeftDoubleClick && v.InRect(event.Position()) {
+			row, column := v.GetSelection()
+			selected(row, column)
+			return action, nil
+		}
+
+		return action, event
+	})
+
+	// The preview always shows the selected file of the focused view, so that we have to update it when the selection
+	// changes or when the user switches between the two views.
+	v.SetSelectionChangedFunc(func(row int, column int) {
+		v.updatePreview()
+	})
+
+	// The status bar and the preview always show the location and the selected file of the focused view and the
+	// breadcrumb of the focused view is highlighted, so that the user always knows which view is focused.
+	v.SetFocusFunc(func() {
+		v.updateStatus()
+		v.updatePreview()
+
+		v.breadcrumb.SetActive(true)
+		if v.otherView != nil {
+			v.otherView.breadcrumb.SetActive(false)
+		}
+	})
+
+	// When a segment of the breadcrumb is selected, we jump to the list of remotes or to the folder of the segment. Like
+	// for the "backspace" key, the folder we came from is selected again.
+	v.breadcrumb.SetSelectedFunc(func(segment string) {
+		if segment == breadcrumbRemotes {
+			remote := v.remote
+			v.renderRemotes(localPath)
+			v.selectName(remote)
+			return
+		}
+
+		n, err := strconv.Atoi(segment)
+		if err != nil || v.remote == "" || n > len(v.remotePath) {
+			return
+		}
+
+		folder := ""
+		if n < len(v.remotePath) {
+			folder = v.remotePath[n]
+		}
+
+		v.remotePath = append([]string{}, v.remotePath[:n]...)
+		v.renderEntries(app)
+		v.selectName(folder)
+	}, func() {
+		app.SetFocus(v)
 	})
 
 	// We have to provide some additional navigation and action option. The default navigation keys can be found in the
@@ -173,92 +727,155 @@ func NewView(app *tview.Application, status *Status, remotes, localPath []string
 		}
 
 		// The "escape" key is used to go back to the remotes selection table. This allows a user to always escaped the
-		// current entries table.
+		// current entries table. The remote we came from is selected again.
 		if event.Key() == tcell.KeyEscape {
+			remote := v.remote
 			v.renderRemotes(localPath)
+			v.selectName(remote)
 		}
 
-		// The "backspace" key is used to went up a directory. If there is no entry in the path list we go back to the
-		// remotes selection table.
+		// The "backspace" key is used to went up a directory.
 		if event.Key() == tcell.KeyBackspace2 && v.remote != "" {
-			if len(v.remotePath) == 0 {
-				v.renderRemotes(localPath)
-			} else {
-				v.remotePath = v.remotePath[:len(v.remotePath)-1]
+			v.goUp(localPath)
+		}
+
+		// The ":" key is used to show the location bar, where the user can enter a remote and path to jump directly
+		// to the location.
+		if event.Rune() == ':' {
+			v.showLocation()
+			return nil
+		}
+
+		// The "P" key is used to select a segment of the breadcrumb, to jump directly to a parent folder.
+		if event.Rune() == 'P' {
+			v.breadcrumb.Select()
+			app.SetFocus(v.breadcrumb)
+			return nil
+		}
+
+		// The "[" and "]" keys are used to navigate backward and forward in the history of visited locations.
+		if event.Rune() == '[' {
+			v.navigateHistory(localPath, -1)
+			return nil
+		}
+
+		if event.Rune() == ']' {
+			v.navigateHistory(localPath, 1)
+			return nil
+		}
+
+		// The "/" key is used to search the entries of the current folder. When a search is active the "n" and "N" keys
+		// can be used to select the next and previous match.
+		if event.Rune() == '/' && v.remote != "" {
+			v.showSearch()
+			return nil
+		}
+
+		if event.Rune() == 'n' && v.search != "" {
+			v.nextMatch(true, false)
+			return nil
+		}
+
+		if event.Rune() == 'N' && v.search != "" {
+			v.nextMatch(false, false)
+			return nil
+		}
+
+		// The "f" key is used to find files and folders in the current folder and all subfolders.
+		if event.Rune() == 'f' && v.remote != "" {
+			v.showFindInput()
+			return nil
+		}
+
+		// The "R" key is used to list the current folder again, even when the listing is cached.
+		if event.Rune() == 'R' && v.remote != "" {
+			v.keepSelection(func() {
+				v.options.Cache.invalidate(fsPath(v.remote, v.remotePath))
 				v.renderEntries(app)
+			})
+		}
+
+		// The "t" key is used to switch between the flat list and the tree mode, where folders can be expanded and
+		// collapsed via the "enter" key.
+		if event.Rune() == 't' {
+			v.treeMode = !v.treeMode
+
+			if v.remote == "" {
+				v.renderHeader()
+			} else {
+				v.keepSelection(func() {
+					v.renderEntries(app)
+				})
 			}
 		}
 
+		// The "." key is used to show or hide hidden files and folders.
+		if event.Rune() == '.' {
+			v.toggleHidden(app)
+			return nil
+		}
+
+		// The "M" key is used to switch the mode in which dates are shown (local time zone, UTC or relative). The mode is
+		// shared between both views, so that we have to render both views again. Rendering the rows only updates the
+		// status bar for the focused view and an active search is highlighted again.
+		if event.Rune() == 'M' {
+			v.options.TimeFormat.toggle()
+
+			for _, view := range []*View{v, v.otherView} {
+				if view == nil {
+					continue
+				}
+
+				if view.remote == "" {
+					view.renderHeader()
+				} else {
+					view.renderRows()
+					view.renderSearch()
+				}
+			}
+
+			return nil
+		}
+
+		// The "C" key is used to select the columns of the view.
+		if event.Rune() == 'C' {
+			v.showColumns(localPath)
+		}
+
+		// The "b" key is used to bookmark the current location and the "B" key is used to show all bookmarks.
+		if event.Rune() == 'b' && v.remote != "" {
+			v.addBookmark()
+		}
+
+		if event.Rune() == 'B' {
+			v.showBookmarks()
+		}
+
 		// The "c" key is used to copy the selected file. When the user presses the "c" key we add the current
 		// file/folder as selected one. The selection is handled by the status component.
 		// We have to check that the user does not selected the header column and that there are enough items in the
 		// entries list for the selection.
 		if event.Rune() == 'c' && v.remote != "" {
 			row, _ := v.GetSelection()
-			if row > 0 && row-1 < len(v.remoteEntries) {
-				v.status.SetSelect(v.remote, append(v.remotePath, v.remoteEntries[row-1].String()), "copy")
+			if v.selectedEntry() != nil {
+				v.status.SetSelect(v.remote, v.entryPath(row), "copy")
 			}
 		}
 
 		// The "p" key is used to paste the selected file/folder. When the user presses the "p" key and selected a file/
-		// folder before with the "c" key the selected file/folder is paste in the current remote/path.
-		// We have to check if the user already selected a remote and path. If this is the case we check if the source
-		// entry is a file or a folder. If it is a file we have to remove the filename from the path and handle this in
-		// an additional variable, so that we can use the operations.CopyFile function to copy the file from the source
-		// (selected) to the destination (v.remote:v.remotePath). If the user selected a folder we can use the sync.Copy
-		// function to copy the folder.
-		if event.Rune() == 'p' && v.remote != "" && v.status.GetAction() == "copy" {
+		// folder before with the "c" key the selected file/folder is paste in the current remote/path. This is not
+		// allowed when the current remote is write protected.
+		// We have to check if the user already selected a remote and path, before we can paste the file/folder.
+		if event.Rune() == 'p' && v.remote != "" && v.status.GetAction() == "copy" && v.checkWritable(v.remote) {
 			selectedRemote := v.status.GetSelectedRemote()
 			selectedPath := v.status.GetSelectedPath()
 
 			if selectedRemote != "" && len(selectedPath) > 0 {
 				v.status.SetSelect(selectedRemote, selectedPath, "paste")
 
-				_, err := fs.NewFs(context.Background(), fsPath(selectedRemote, selectedPath))
-				if err != nil {
-					if err == fs.ErrorIsFile {
-						path, filename := fsPathFilename(selectedPath)
-
-						fsrc, err := fs.NewFs(context.Background(), fsPath(selectedRemote, path))
-						if err != nil {
-							app.Stop()
-							log.Fatalf("Could not create new fsrc object: %#v", err)
-						}
-
-						fdst, err := fs.NewFs(context.Background(), fsPath(v.remote, v.remotePath))
-						if err != nil {
-							app.Stop()
-							log.Fatalf("Could not create new fdst object: %#v", err)
-						}
-
-						err = operations.CopyFile(context.Background(), fdst, fsrc, filename, filename)
-						if err != nil {
-							app.Stop()
-							log.Fatalf("Could not copy/paste file: %#v", err)
-						}
-					} else {
-						app.Stop()
-						log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(selectedRemote, selectedPath), err)
-					}
-				} else {
-					fsrc, err := fs.NewFs(context.Background(), fsPath(selectedRemote, selectedPath))
-					if err != nil {
-						app.Stop()
-						log.Fatalf("Could not create new fsrc object: %#v", err)
-					}
-
-					fdst, err := fs.NewFs(context.Background(), fsPath(v.remote, append(v.remotePath, selectedPath[len(selectedPath)-1])))
-					if err != nil {
-						app.Stop()
-						log.Fatalf("Could not create new fdst object: %#v", err)
-					}
-
-					err = sync.CopyDir(context.Background(), fdst, fsrc, true)
-					if err != nil {
-						app.Stop()
-						log.Fatalf("Could not copy/paste folder: %#v", err)
-					}
-				}
+				v.mutate(auditEntry{Action: "paste", Source: fsPath(selectedRemote, selectedPath), Destination: fsPath(v.remote, v.remotePath)}, func(ctx context.Context) error {
+					return v.paste(ctx, selectedRemote, selectedPath)
+				})
 
 				v.renderEntries(app)
 			}
@@ -266,66 +883,91 @@ func NewView(app *tview.Application, status *Status, remotes, localPath []string
 			v.status.SetSelect("", nil, "")
 		}
 
+		// The "v" key is used to show or hide the preview of the selected file.
+		if event.Rune() == 'v' {
+			v.preview.Toggle()
+			v.updatePreview()
+		}
+
+		// The "s" key is used to show all hashes of the selected file. Folders do not have a hash, so that we ignore the
+		// key when a folder is selected.
+		if event.Rune() == 's' && v.remote != "" {
+			if o, ok := v.selectedEntry().(fs.Object); ok {
+				v.showHashes(o)
+			}
+		}
+
+		// The "i" key is used to show all available information, like the MIME type, storage tier and metadata, of the
+		// selected file/folder.
+		if event.Rune() == 'i' && v.remote != "" {
+			if entry := v.selectedEntry(); entry != nil {
+				v.showInfo(entry)
+			}
+		}
+
+		// The "e" key is used to edit the selected file in the users editor. The file is uploaded again, when it was
+		// changed.
+		if event.Rune() == 'e' && v.remote != "" && v.checkWritable(v.remote) {
+			if o, ok := v.selectedEntry().(fs.Object); ok {
+				v.edit(o)
+			}
+		}
+
+		// The "o" key is used to open the selected file/folder with the configured open command.
+		if event.Rune() == 'o' && v.remote != "" {
+			if entry := v.selectedEntry(); entry != nil {
+				v.open(entry)
+			}
+		}
+
+		// The "D" key is used to enable or disable the dry-run mode. In the dry-run mode all actions which modify a
+		// remote are only simulated and the user is informed what would have happened.
+		if event.Rune() == 'D' {
+			v.options.Config.DryRun = !v.options.Config.DryRun
+			v.status.SetDryRun(v.options.Config.DryRun)
+		}
+
+		// The "u" key is used to undo the last deletion, by restoring the last file/folder which was moved into the
+		// trash. The "T" key is used to show all files/folders in the trash of the current remote.
+		if event.Rune() == 'u' && v.remote != "" && v.options.Trash != nil {
+			v.undo()
+		}
+
+		if event.Rune() == 'T' && v.remote != "" && v.options.Trash != nil {
+			v.showTrash()
+		}
+
+		// The "A" key is used to show the audit log, which contains all actions which modified a remote.
+		if event.Rune() == 'A' && v.options.AuditLog != nil {
+			v.showAuditLog()
+		}
+
+		// The "S" key is used to export the MD5 hashes of all files in the current remote/path into an md5sum
+		// compatible file in the users local directory.
+		if event.Rune() == 'S' && v.remote != "" && v.checkWritable(Local) {
+			v.exportHashes(localPath)
+		}
+
 		// The "d" key is used to delete the a file/folder. When the user presses the "d" key the first time the file/
-		// folder is selected for delition. When the user presses the "d" key another time the selected file is deleted.
-		// When the users presses another key in between, the selection is removed.
+		// folder is selected for delition. When the user presses the "d" key another time the selected file is deleted,
+		// large deletions must be confirmed by the user in a dialog. When the users presses another key in between, the
+		// selection is removed.
 		if event.Rune() == 'd' && v.remote != "" {
 			if v.status.GetAction() == "delete" {
 				// User presses the "d" key the second time.
 				selectedRemote := v.status.GetSelectedRemote()
 				selectedPath := v.status.GetSelectedPath()
 
+				v.status.SetSelect("", nil, "")
+
 				if selectedRemote != "" && len(selectedPath) > 0 {
-					if selectedRemote == Local {
-						err := os.RemoveAll(fsPath(selectedRemote, selectedPath))
-						if err != nil {
-							app.Stop()
-							log.Fatalf("Could not delete file: %#v", err)
-						}
-					} else {
-						f, err := fs.NewFs(context.Background(), fsPath(selectedRemote, selectedPath))
-						if err != nil {
-							if err == fs.ErrorIsFile {
-								path, filename := fsPathFilename(selectedPath)
-
-								fdst, err := fs.NewFs(context.Background(), fsPath(selectedRemote, path))
-								if err != nil {
-									app.Stop()
-									log.Fatalf("Could not create new fsrc object: %#v", err)
-								}
-
-								dst, err := fdst.NewObject(context.Background(), filename)
-								if err != nil {
-									app.Stop()
-									log.Fatalf("Could not get file for deletion: %#v", err)
-								}
-
-								err = operations.DeleteFile(context.Background(), dst)
-								if err != nil {
-									app.Stop()
-									log.Fatalf("Could not delete file: %#v", err)
-								}
-							} else {
-								app.Stop()
-								log.Fatalf("Could not create new fs object for \"%s\": %#v", fsPath(selectedRemote, selectedPath), err)
-							}
-						} else {
-							err := operations.Delete(context.Background(), f)
-							if err != nil {
-								app.Stop()
-								log.Fatalf("Could not delete folder: %#v", err)
-							}
-						}
-					}
+					v.confirmDelete(selectedRemote, selectedPath, nil)
 				}
-
-				v.renderEntries(app)
-				v.status.SetSelect("", nil, "")
 			} else {
 				// User presses the "d" key the first time.
 				row, _ := v.GetSelection()
-				if row > 0 && row-1 < len(v.remoteEntries) && len(v.remotePath) != 0 {
-					v.status.SetSelect(v.remote, append(v.remotePath, v.remoteEntries[row-1].String()), "delete")
+				if v.selectedEntry() != nil && len(v.remotePath) != 0 && v.checkWritable(v.remote) {
+					v.status.SetSelect(v.remote, v.entryPath(row), "delete")
 				}
 			}
 		} else {
@@ -0,0 +1,228 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rclone/rclone/fs"
+)
+
+const (
+	// watchDelay is the time we wait after a change was detected, before the entries are refreshed. Changes often come
+	// in bursts (e.g. when multiple files are uploaded), so that we only want to refresh the entries once.
+	watchDelay = 500 * time.Millisecond
+)
+
+// entriesSnapshot returns a string with the name, size and modification time of all given entries, which can be used
+// to check if the entries of a folder were changed.
+func entriesSnapshot(ctx context.Context, entries fs.DirEntries) string {
+	var s strings.Builder
+	for _, entry := range entries {
+		fmt.Fprintf(&s, "%s %d %s\n", entry.Remote(), entry.Size(), entry.ModTime(ctx))
+	}
+
+	return s.String()
+}
+
+// keepSelection runs the given function, which renders the entries again, and selects the previously selected entry
+// and scroll offset afterwards. When the entry does not exist anymore, the previously selected row is selected.
+func (v *View) keepSelection(render func()) {
+	row, _ := v.GetSelection()
+	offset, _ := v.GetOffset()
+
+	name := ""
+	if entry := v.selectedEntry(); entry != nil {
+		name = entry.Remote()
+	}
+
+	render()
+
+	v.SetOffset(offset, 0)
+	v.Select(row, 0)
+	v.selectName(name)
+}
+
+// watch starts watching the current folder for changes, so that the entries are refreshed automatically. Local folders
+// are watched via filesystem notifications. For remotes which support change notifications (e.g. Google Drive) we use
+// the notifications of the remote. All other remotes are listed again in the configured interval, when polling is
+// enabled.
+// When the current folder is already watched nothing is done. Watching the previous folder is always stopped.
+func (v *View) watch() {
+	path := fsPath(v.remote, v.remotePath)
+	if v.remote != "" && v.watchPath == path {
+		return
+	}
+
+	if v.watchCancel != nil {
+		v.watchCancel()
+	}
+
+	v.watchPath = ""
+	v.watchCancel = nil
+
+	if v.remote == "" {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	v.watchPath = path
+	v.watchCancel = cancel
+
+	changed := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	if v.remote == Local {
+		go v.watchLocal(ctx, path, notify)
+	} else if v.options.RefreshInterval > 0 {
+		f, err := newFs(ctx, path)
+		if err != nil {
+			cancel()
+			return
+		}
+
+		if f.Features().ChangeNotify != nil {
+			v.watchChanges(ctx, f, notify)
+		} else if v.options.RefreshPoll {
+			go v.watchPoll(ctx, f, notify)
+		}
+	}
+
+	go v.refreshOnChange(ctx, path, changed)
+}
+
+// watchLocal watches the given local folder via filesystem notifications and calls the notify function for each change.
+// Because the entries of the folder can be cached, we also call the notify function once after the watcher was added,
+// so that changes which were made while the folder was not watched are shown.
+func (v *View) watchLocal(ctx context.Context, path string, notify func()) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(path); err != nil {
+		return
+	}
+
+	notify()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			notify()
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// watchChanges subscribes to the change notifications of the given Fs and calls the notify function, when a file or
+// folder in the root of the Fs was changed. The changes are polled by the remote in the configured interval.
+func (v *View) watchChanges(ctx context.Context, f fs.Fs, notify func()) {
+	pollInterval := make(chan time.Duration, 1)
+	pollInterval <- v.options.RefreshInterval
+
+	f.Features().ChangeNotify(ctx, func(path string, entryType fs.EntryType) {
+		if !strings.Contains(strings.Trim(path, "/"), "/") {
+			notify()
+		}
+	}, pollInterval)
+
+	// Closing the channel stops the change notifications of the remote.
+	go func() {
+		<-ctx.Done()
+		close(pollInterval)
+	}()
+}
+
+// watchPoll lists the given Fs in the configured interval and calls the notify function, when the entries were changed
+// since the last listing.
+func (v *View) watchPoll(ctx context.Context, f fs.Fs, notify func()) {
+	snapshot := func() string {
+		entries, err := v.listEntries(ctx, f, "")
+		if err != nil {
+			return ""
+		}
+
+		return entriesSnapshot(ctx, entries)
+	}
+
+	last := snapshot()
+
+	ticker := time.NewTicker(v.options.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if current := snapshot(); current != last {
+				last = current
+				notify()
+			}
+		}
+	}
+}
+
+// refreshOnChange refreshes the entries of the given path, when a change is signaled via the changed channel. The
+// entries are listed in the background and only rendered, when the view still shows the same path and the entries were
+// changed. When the folder can not be listed anymore (e.g. because it was deleted), the entries are not refreshed.
+func (v *View) refreshOnChange(ctx context.Context, path string, changed <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-changed:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchDelay):
+		}
+
+		f, err := newFs(ctx, path)
+		if err != nil {
+			continue
+		}
+
+		entries, err := v.listEntries(ctx, f, "")
+		if err != nil || ctx.Err() != nil {
+			continue
+		}
+
+		v.app.QueueUpdateDraw(func() {
+			if v.watchPath != path || entriesSnapshot(ctx, v.visibleEntries(entries)) == entriesSnapshot(ctx, v.remoteEntries) {
+				return
+			}
+
+			// The refresh is not triggered by the user, so that an active search is kept and highlighted again.
+			search := v.search
+
+			v.keepSelection(func() {
+				v.remoteEntries = entries
+				v.options.Cache.set(path, entries)
+				v.renderListing()
+
+				v.search = search
+				v.renderSearch()
+			})
+		})
+	}
+}